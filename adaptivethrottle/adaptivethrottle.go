@@ -0,0 +1,254 @@
+package adaptivethrottle
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/util"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// ErrThrottled is returned when an execution is rejected by an AdaptiveThrottler.
+var ErrThrottled = errors.New("throttled")
+
+/*
+AdaptiveThrottler is a policy that rejects executions locally, with a probability based on how many recent executions
+were accepted by the system being called, as described in the Google SRE book's client-side throttling. The throttler
+tracks requests, which are all executions that were attempted, including those that were rejected, and accepts, which
+are executions that did not fail according to the throttler's handle conditions. Once requests exceed K * accepts, where
+K is the accept multiplier, executions are rejected with ErrThrottled with a probability of:
+
+	max(0, (requests - K * accepts) / (requests + 1))
+
+Requests and accepts are tracked over a sliding window, which is divided into 10 time slices, each representing 1/10th
+of the window. As time progresses, statistics for old time slices are gradually discarded, which smooths the rejection
+rate. Unlike a CircuitBreaker, which rejects all executions while open, an AdaptiveThrottler rejects a portion of
+executions that grows and shrinks with the failure rate.
+
+This type is concurrency safe.
+*/
+type AdaptiveThrottler[R any] interface {
+	failsafe.Policy[R]
+
+	// TryAcquirePermit records a request and returns whether it's allowed, based on the current rejection rate. Callers
+	// should record the result of an allowed request via one of the Record methods.
+	TryAcquirePermit() bool
+
+	// RecordResult records an execution result as an accept if it's not a failure based on the failure handling
+	// configuration.
+	RecordResult(result R)
+
+	// RecordError records an error as an accept if it's not a failure based on the failure handling configuration.
+	RecordError(err error)
+
+	// RecordAccept records an execution that was accepted.
+	RecordAccept()
+
+	// RejectionRate returns the current probability, from 0 to 1, that an execution will be rejected.
+	RejectionRate() float64
+
+	// Metrics returns metrics for the AdaptiveThrottler.
+	Metrics() Metrics
+}
+
+// Metrics contains counters for an AdaptiveThrottler, within its current window.
+type Metrics interface {
+	// Requests returns the number of requests recorded within the window, including those that were rejected.
+	Requests() uint
+
+	// Accepts returns the number of accepts recorded within the window.
+	Accepts() uint
+}
+
+/*
+AdaptiveThrottlerBuilder builds AdaptiveThrottler instances.
+
+  - By default, any error is considered a failure, and executions that do not fail are considered accepted. You can
+    override this by specifying your own handle conditions. The default error handling condition will only be overridden
+    by another condition that handles errors such as HandleErrors or HandleIf. Specifying a condition that only handles
+    results, such as HandleResult, will not replace the default error handling condition.
+  - If multiple handle conditions are specified, any condition that matches an execution result or error will cause the
+    execution to not be accepted.
+
+This type is not concurrency safe.
+*/
+type AdaptiveThrottlerBuilder[R any] interface {
+	failsafe.FailurePolicyBuilder[AdaptiveThrottlerBuilder[R], R]
+
+	// WithAcceptMultiplier configures K, the multiplier for accepts that requests must exceed before executions are
+	// rejected. Lower values reject executions more aggressively. Defaults to 2.
+	//
+	// Panics if k < 1.
+	WithAcceptMultiplier(k float64) AdaptiveThrottlerBuilder[R]
+
+	// WithWindow configures the sliding window that requests and accepts are tracked over. Defaults to 2 minutes.
+	//
+	// Panics if window <= 0.
+	WithWindow(window time.Duration) AdaptiveThrottlerBuilder[R]
+
+	// WithMaxRejectionRate configures the max rejection rate, from 0 to 1, so that some executions are always allowed
+	// through. Defaults to 1.
+	//
+	// Panics if maxRejectionRate is not > 0 and <= 1.
+	WithMaxRejectionRate(maxRejectionRate float64) AdaptiveThrottlerBuilder[R]
+
+	// OnThrottled registers the listener to be called when an execution is rejected.
+	OnThrottled(listener func(event failsafe.ExecutionEvent[R])) AdaptiveThrottlerBuilder[R]
+
+	// Build returns a new AdaptiveThrottler using the builder's configuration.
+	Build() AdaptiveThrottler[R]
+}
+
+type adaptiveThrottlerConfig[R any] struct {
+	*policy.BaseFailurePolicy[R]
+	clock            util.Clock
+	random           func() float64
+	acceptMultiplier float64
+	window           time.Duration
+	maxRejectionRate float64
+	onThrottled      func(failsafe.ExecutionEvent[R])
+}
+
+var _ AdaptiveThrottlerBuilder[any] = &adaptiveThrottlerConfig[any]{}
+
+// WithDefaults returns a new AdaptiveThrottler for execution result type R with an accept multiplier of 2 and a window
+// of 2 minutes.
+func WithDefaults[R any]() AdaptiveThrottler[R] {
+	return Builder[R]().Build()
+}
+
+// Builder returns an AdaptiveThrottlerBuilder for execution result type R, with an accept multiplier of 2 and a window of
+// 2 minutes by default.
+func Builder[R any]() AdaptiveThrottlerBuilder[R] {
+	return &adaptiveThrottlerConfig[R]{
+		BaseFailurePolicy: &policy.BaseFailurePolicy[R]{},
+		clock:             util.NewClock(),
+		random:            rand.Float64,
+		acceptMultiplier:  2,
+		window:            2 * time.Minute,
+		maxRejectionRate:  1,
+	}
+}
+
+func (c *adaptiveThrottlerConfig[R]) HandleErrors(errs ...error) AdaptiveThrottlerBuilder[R] {
+	c.BaseFailurePolicy.HandleErrors(errs...)
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) HandleResult(result R) AdaptiveThrottlerBuilder[R] {
+	c.BaseFailurePolicy.HandleResult(result)
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) HandleIf(predicate func(R, error) bool) AdaptiveThrottlerBuilder[R] {
+	c.BaseFailurePolicy.HandleIf(predicate)
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) OnSuccess(listener func(event failsafe.ExecutionEvent[R])) AdaptiveThrottlerBuilder[R] {
+	c.BaseFailurePolicy.OnSuccess(listener)
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) OnFailure(listener func(event failsafe.ExecutionEvent[R])) AdaptiveThrottlerBuilder[R] {
+	c.BaseFailurePolicy.OnFailure(listener)
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) WithAcceptMultiplier(k float64) AdaptiveThrottlerBuilder[R] {
+	util.Assert(k >= 1, "k must be >= 1")
+	c.acceptMultiplier = k
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) WithWindow(window time.Duration) AdaptiveThrottlerBuilder[R] {
+	util.Assert(window > 0, "window must be > 0")
+	c.window = window
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) WithMaxRejectionRate(maxRejectionRate float64) AdaptiveThrottlerBuilder[R] {
+	util.Assert(maxRejectionRate > 0 && maxRejectionRate <= 1, "maxRejectionRate must be > 0 and <= 1")
+	c.maxRejectionRate = maxRejectionRate
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) OnThrottled(listener func(event failsafe.ExecutionEvent[R])) AdaptiveThrottlerBuilder[R] {
+	c.onThrottled = listener
+	return c
+}
+
+func (c *adaptiveThrottlerConfig[R]) Build() AdaptiveThrottler[R] {
+	return &adaptiveThrottler[R]{
+		config: c, // TODO copy base fields
+		stats:  newThrottleStats(c.window, c.clock),
+	}
+}
+
+type adaptiveThrottler[R any] struct {
+	config *adaptiveThrottlerConfig[R]
+	stats  *throttleStats
+}
+
+func (t *adaptiveThrottler[R]) TryAcquirePermit() bool {
+	return t.stats.recordRequest(func(requests, accepts uint) bool {
+		return t.config.random() >= t.rejectionRate(requests, accepts)
+	})
+}
+
+func (t *adaptiveThrottler[R]) RecordResult(result R) {
+	t.recordResult(result, nil)
+}
+
+func (t *adaptiveThrottler[R]) RecordError(err error) {
+	t.recordResult(*(new(R)), err)
+}
+
+func (t *adaptiveThrottler[R]) RecordAccept() {
+	t.stats.recordAccept()
+}
+
+func (t *adaptiveThrottler[R]) RejectionRate() float64 {
+	requests, accepts := t.stats.get()
+	return t.rejectionRate(requests, accepts)
+}
+
+func (t *adaptiveThrottler[R]) Metrics() Metrics {
+	return t
+}
+
+func (t *adaptiveThrottler[R]) Requests() uint {
+	requests, _ := t.stats.get()
+	return requests
+}
+
+func (t *adaptiveThrottler[R]) Accepts() uint {
+	_, accepts := t.stats.get()
+	return accepts
+}
+
+func (t *adaptiveThrottler[R]) ToExecutor(_ R) any {
+	te := &adaptiveThrottlerExecutor[R]{
+		BaseExecutor: &policy.BaseExecutor[R]{
+			BaseFailurePolicy: t.config.BaseFailurePolicy,
+		},
+		adaptiveThrottler: t,
+	}
+	te.Executor = te
+	return te
+}
+
+func (t *adaptiveThrottler[R]) recordResult(result R, err error) {
+	if !t.config.IsFailure(result, err) {
+		t.stats.recordAccept()
+	}
+}
+
+// rejectionRate computes the rejection rate for the requests and accepts.
+func (t *adaptiveThrottler[R]) rejectionRate(requests, accepts uint) float64 {
+	rate := (float64(requests) - t.config.acceptMultiplier*float64(accepts)) / float64(requests+1)
+	return math.Min(math.Max(0, rate), t.config.maxRejectionRate)
+}
@@ -0,0 +1,132 @@
+package adaptivethrottle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+func TestBuilderShouldValidate(t *testing.T) {
+	assert.Panics(t, func() {
+		Builder[any]().WithAcceptMultiplier(.5)
+	})
+	assert.Panics(t, func() {
+		Builder[any]().WithWindow(0)
+	})
+	assert.Panics(t, func() {
+		Builder[any]().WithMaxRejectionRate(0)
+	})
+	assert.Panics(t, func() {
+		Builder[any]().WithMaxRejectionRate(1.1)
+	})
+	assert.NotPanics(t, func() {
+		Builder[any]().WithAcceptMultiplier(1).WithWindow(time.Second).WithMaxRejectionRate(1)
+	})
+}
+
+func TestRejectionRate(t *testing.T) {
+	throttler := throttlerWithClock(Builder[any](), &testutil.TestClock{})
+
+	// No requests
+	assert.Equal(t, 0.0, throttler.RejectionRate())
+
+	// Requests within K * accepts
+	for i := 0; i < 10; i++ {
+		throttler.TryAcquirePermit()
+		if i%2 == 0 {
+			throttler.RecordAccept()
+		}
+	}
+	assert.Equal(t, 0.0, throttler.RejectionRate())
+
+	// Requests exceed K * accepts
+	for i := 0; i < 10; i++ {
+		throttler.TryAcquirePermit()
+	}
+	assert.Equal(t, uint(20), throttler.Metrics().Requests())
+	assert.Equal(t, uint(5), throttler.Metrics().Accepts())
+	assert.InDelta(t, 10.0/21.0, throttler.RejectionRate(), .0001)
+}
+
+func TestMaxRejectionRate(t *testing.T) {
+	throttler := throttlerWithClock(Builder[any]().WithMaxRejectionRate(.5), &testutil.TestClock{})
+	for i := 0; i < 100; i++ {
+		throttler.TryAcquirePermit()
+	}
+	assert.Equal(t, .5, throttler.RejectionRate())
+}
+
+func TestShouldDiscardOldStats(t *testing.T) {
+	clock := &testutil.TestClock{}
+	throttler := throttlerWithClock(Builder[any]().WithWindow(10*time.Second), clock)
+	for i := 0; i < 10; i++ {
+		throttler.TryAcquirePermit()
+	}
+	clock.CurrentTime = testutil.MillisToNanos(5000)
+	throttler.TryAcquirePermit()
+	throttler.RecordAccept()
+	assert.Equal(t, uint(11), throttler.Metrics().Requests())
+
+	clock.CurrentTime = testutil.MillisToNanos(10000)
+	assert.Equal(t, uint(1), throttler.Metrics().Requests())
+	assert.Equal(t, uint(1), throttler.Metrics().Accepts())
+
+	clock.CurrentTime = testutil.MillisToNanos(30000)
+	assert.Equal(t, uint(0), throttler.Metrics().Requests())
+}
+
+func TestRecordResult(t *testing.T) {
+	throttler := throttlerWithClock(Builder[int]().HandleResult(500), &testutil.TestClock{})
+	throttler.RecordResult(200)
+	throttler.RecordResult(500)
+	throttler.RecordError(testutil.ErrInvalidState)
+	assert.Equal(t, uint(1), throttler.Metrics().Accepts())
+}
+
+// Tests that executions are rejected with ErrThrottled based on the rejection rate, and that accepts are based on the
+// handle conditions.
+func TestShouldThrottleExecutions(t *testing.T) {
+	// Given
+	throttled := 0
+	random := .99
+	builder := Builder[int]().
+		HandleResult(500).
+		OnThrottled(func(e failsafe.ExecutionEvent[int]) {
+			throttled++
+		})
+	builder.(*adaptiveThrottlerConfig[int]).random = func() float64 {
+		return random
+	}
+	throttler := throttlerWithClock(builder, &testutil.TestClock{})
+
+	// When / Then
+	for i := 0; i < 4; i++ {
+		result, err := failsafe.Get(func() (int, error) { return 500, nil }, throttler)
+		assert.Equal(t, 500, result)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, uint(0), throttler.Metrics().Accepts())
+
+	// Rejection rate is 4/5
+	random = .79
+	_, err := failsafe.Get(func() (int, error) { return 200, nil }, throttler)
+	assert.ErrorIs(t, err, ErrThrottled)
+	assert.Equal(t, 1, throttled)
+
+	// Rejection rate is 5/6
+	random = .84
+	result, err := failsafe.Get(func() (int, error) { return 200, nil }, throttler)
+	assert.Equal(t, 200, result)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), throttler.Metrics().Accepts())
+	assert.Equal(t, uint(6), throttler.Metrics().Requests())
+}
+
+func throttlerWithClock[R any](builder AdaptiveThrottlerBuilder[R], clock *testutil.TestClock) AdaptiveThrottler[R] {
+	builder.(*adaptiveThrottlerConfig[R]).clock = clock
+	return builder.Build()
+}
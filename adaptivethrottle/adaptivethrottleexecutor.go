@@ -0,0 +1,32 @@
+package adaptivethrottle
+
+import (
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/internal"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// adaptiveThrottlerExecutor is a policy.Executor that handles failures according to an AdaptiveThrottler.
+type adaptiveThrottlerExecutor[R any] struct {
+	*policy.BaseExecutor[R]
+	*adaptiveThrottler[R]
+}
+
+var _ policy.Executor[any] = &adaptiveThrottlerExecutor[any]{}
+
+func (e *adaptiveThrottlerExecutor[R]) PreExecute(exec policy.ExecutionInternal[R]) *common.PolicyResult[R] {
+	if !e.TryAcquirePermit() {
+		e.RecordEvent(exec, failsafe.PolicyEvent{Type: failsafe.Throttled, Policy: "AdaptiveThrottler", Source: e.adaptiveThrottler, Error: ErrThrottled})
+		if e.config.onThrottled != nil {
+			e.config.onThrottled(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(nil)})
+		}
+		return internal.FailureResult[R](ErrThrottled)
+	}
+	return nil
+}
+
+func (e *adaptiveThrottlerExecutor[R]) OnSuccess(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) {
+	e.BaseExecutor.OnSuccess(exec, result)
+	e.RecordAccept()
+}
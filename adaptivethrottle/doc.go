@@ -0,0 +1,2 @@
+// Package adaptivethrottle provides an AdaptiveThrottler policy.
+package adaptivethrottle
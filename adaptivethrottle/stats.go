@@ -0,0 +1,58 @@
+package adaptivethrottle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/internal/util"
+)
+
+// The number of buckets that a throttle window is divided into.
+const statsBucketCount = 10
+
+// Kinds of counts tracked by throttle stats.
+const (
+	statsRequests = iota
+	statsAccepts
+)
+
+// throttleStats tracks requests and accepts within a sliding time window. The window is divided into buckets, each
+// representing 1/10th of the window. As time progresses, counts for old buckets are discarded.
+//
+// This type is concurrency safe.
+type throttleStats struct {
+	mtx sync.Mutex
+
+	// Guarded by mtx
+	counts *util.RollingCounts
+}
+
+func newThrottleStats(window time.Duration, clock util.Clock) *throttleStats {
+	return &throttleStats{
+		counts: util.NewRollingCounts(2, window, statsBucketCount, clock),
+	}
+}
+
+// recordRequest records a request and returns whether it's allowed by the allowFn, which is called with the requests and
+// accepts prior to the current request.
+func (s *throttleStats) recordRequest(allowFn func(requests, accepts uint) bool) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	allowed := allowFn(s.counts.Get(statsRequests), s.counts.Get(statsAccepts))
+	s.counts.Add(statsRequests)
+	return allowed
+}
+
+// recordAccept records an accepted request.
+func (s *throttleStats) recordAccept() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.counts.Add(statsAccepts)
+}
+
+// get returns the requests and accepts within the window.
+func (s *throttleStats) get() (requests uint, accepts uint) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.counts.Get(statsRequests), s.counts.Get(statsAccepts)
+}
@@ -3,6 +3,8 @@ package bulkhead
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/semaphore"
@@ -30,7 +32,7 @@ type Bulkhead[R any] interface {
 	// AcquirePermitWithMaxWait attempts to acquire a permit to perform an execution within the Bulkhead, waiting up to the
 	// maxWaitTime until one is available or the ctx is canceled. Returns ErrFull if a permit could not be acquired
 	// in time. Returns context.Canceled if the ctx is canceled. Callers should call ReleasePermit to release a successfully
-	// acquired permit back to the Bulkhead.
+	// acquired permit back to the Bulkhead. A maxWaitTime <= 0 acquires a permit without waiting, as with TryAcquirePermit.
 	//
 	// ctx may be nil.
 	AcquirePermitWithMaxWait(ctx context.Context, maxWaitTime time.Duration) error
@@ -42,6 +44,16 @@ type Bulkhead[R any] interface {
 	// waiting. Returns true if the permit was acquired, else false. Callers should call ReleasePermit to release a
 	// successfully acquired permit back to the Bulkhead.
 	TryAcquirePermit() bool
+
+	// Metrics returns metrics for the Bulkhead.
+	Metrics() Metrics
+}
+
+// Metrics contains metrics for a Bulkhead.
+type Metrics interface {
+	// InFlight returns the number of permits that are currently acquired, which is the number of executions that are in
+	// flight within the Bulkhead.
+	InFlight() uint
 }
 
 // BulkheadBuilder builds Bulkhead instances.
@@ -54,6 +66,9 @@ type BulkheadBuilder[R any] interface {
 	// OnFull registers the listener to be called when the bulkhead is full.
 	OnFull(listener func(event failsafe.ExecutionEvent[R])) BulkheadBuilder[R]
 
+	// WithLogger configures the logger to log the Bulkhead's events to. See failsafe.Logger.
+	WithLogger(logger *slog.Logger) BulkheadBuilder[R]
+
 	// Build returns a new Bulkhead using the builder's configuration.
 	Build() Bulkhead[R]
 }
@@ -62,6 +77,7 @@ type bulkheadConfig[R any] struct {
 	maxConcurrency uint
 	maxWaitTime    time.Duration
 	onFull         func(failsafe.ExecutionEvent[R])
+	logger         *failsafe.Logger
 }
 
 func (c *bulkheadConfig[R]) WithMaxWaitTime(maxWaitTime time.Duration) BulkheadBuilder[R] {
@@ -74,6 +90,11 @@ func (c *bulkheadConfig[R]) OnFull(listener func(event failsafe.ExecutionEvent[R
 	return c
 }
 
+func (c *bulkheadConfig[R]) WithLogger(logger *slog.Logger) BulkheadBuilder[R] {
+	c.logger = failsafe.NewLoggerBuilder(logger).Build()
+	return c
+}
+
 func (c *bulkheadConfig[R]) Build() Bulkhead[R] {
 	return &bulkhead[R]{
 		config:    c, // TODO copy base fields
@@ -98,6 +119,7 @@ func Builder[R any](maxConcurrency uint) BulkheadBuilder[R] {
 type bulkhead[R any] struct {
 	config    *bulkheadConfig[R]
 	semaphore *semaphore.Weighted
+	inFlight  atomic.Int64
 }
 
 func (b *bulkhead[R]) AcquirePermit(ctx context.Context) error {
@@ -107,16 +129,25 @@ func (b *bulkhead[R]) AcquirePermit(ctx context.Context) error {
 	if err := b.semaphore.Acquire(ctx, 1); err != nil {
 		return ErrFull
 	}
+	b.inFlight.Add(1)
 	return nil
 }
 
 func (b *bulkhead[R]) AcquirePermitWithMaxWait(ctx context.Context, maxWaitTime time.Duration) error {
+	if maxWaitTime <= 0 {
+		if b.TryAcquirePermit() {
+			return nil
+		}
+		return ErrFull
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	ctx, cancel := context.WithTimeout(ctx, maxWaitTime)
 	err := b.semaphore.Acquire(ctx, 1)
-	if err != nil && errors.Is(err, context.DeadlineExceeded) {
+	if err == nil {
+		b.inFlight.Add(1)
+	} else if errors.Is(err, context.DeadlineExceeded) {
 		err = ErrFull
 	}
 	cancel()
@@ -124,17 +155,32 @@ func (b *bulkhead[R]) AcquirePermitWithMaxWait(ctx context.Context, maxWaitTime
 }
 
 func (b *bulkhead[R]) TryAcquirePermit() bool {
-	return b.semaphore.TryAcquire(1)
+	if b.semaphore.TryAcquire(1) {
+		b.inFlight.Add(1)
+		return true
+	}
+	return false
 }
 
 func (b *bulkhead[R]) ReleasePermit() {
+	b.inFlight.Add(-1)
 	b.semaphore.Release(1)
 }
 
+func (b *bulkhead[R]) Metrics() Metrics {
+	return b
+}
+
+func (b *bulkhead[R]) InFlight() uint {
+	return uint(max(b.inFlight.Load(), 0))
+}
+
 func (b *bulkhead[R]) ToExecutor(_ R) any {
 	be := &bulkheadExecutor[R]{
-		BaseExecutor: &policy.BaseExecutor[R]{},
-		bulkhead:     b,
+		BaseExecutor: &policy.BaseExecutor[R]{
+			Logger: b.config.logger,
+		},
+		bulkhead: b,
 	}
 	be.Executor = be
 	return be
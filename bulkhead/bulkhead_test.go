@@ -34,6 +34,13 @@ func TestAcquirePermitWithMaxWaitTime(t *testing.T) {
 	assert.ErrorIs(t, ErrFull, err)
 }
 
+func TestAcquirePermitWithZeroMaxWaitTime(t *testing.T) {
+	bulkhead := With[any](1)
+
+	assert.Nil(t, bulkhead.AcquirePermitWithMaxWait(nil, 0))
+	assert.ErrorIs(t, bulkhead.AcquirePermitWithMaxWait(nil, 0), ErrFull)
+}
+
 func TestTryAcquirePermitAndReleasePermit(t *testing.T) {
 	bulkhead := With[any](2)
 
@@ -51,3 +58,18 @@ func TestTryAcquirePermitAndReleasePermit(t *testing.T) {
 	assert.True(t, bulkhead.TryAcquirePermit())
 	assert.False(t, bulkhead.TryAcquirePermit())
 }
+
+func TestInFlight(t *testing.T) {
+	bulkhead := With[any](2)
+
+	assert.True(t, bulkhead.TryAcquirePermit())
+	assert.Nil(t, bulkhead.AcquirePermit(nil))
+	assert.Equal(t, uint(2), bulkhead.Metrics().InFlight())
+	assert.ErrorIs(t, bulkhead.AcquirePermitWithMaxWait(nil, 10*time.Millisecond), ErrFull)
+	assert.Equal(t, uint(2), bulkhead.Metrics().InFlight())
+
+	bulkhead.ReleasePermit()
+	assert.Equal(t, uint(1), bulkhead.Metrics().InFlight())
+	assert.Nil(t, bulkhead.AcquirePermitWithMaxWait(nil, 10*time.Millisecond))
+	assert.Equal(t, uint(2), bulkhead.Metrics().InFlight())
+}
@@ -19,6 +19,7 @@ func (e *bulkheadExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.
 	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
 		execInternal := exec.(policy.ExecutionInternal[R])
 		if err := e.AcquirePermitWithMaxWait(execInternal.Context(), e.config.maxWaitTime); err != nil {
+			e.RecordEvent(execInternal, failsafe.PolicyEvent{Type: failsafe.BulkheadFull, Policy: "Bulkhead", Source: e.bulkhead, Error: err})
 			if e.config.onFull != nil {
 				e.config.onFull(failsafe.ExecutionEvent[R]{
 					ExecutionAttempt: execInternal,
@@ -26,6 +27,7 @@ func (e *bulkheadExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.
 			}
 			return internal.FailureResult[R](err)
 		}
+		defer e.ReleasePermit()
 		return innerFn(exec)
 	}
 }
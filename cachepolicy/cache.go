@@ -1,16 +1,22 @@
 package cachepolicy
 
 import (
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/util"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
 type key int
 
-// CacheKey is a key to use with a Context that stores the cache key.
+// CacheKey is a key to use with a Context that stores the cache key, as either a string or a Keyer.
 const CacheKey key = 0
 
-// Cache is a simple interface for cached values that can be adapted to different cache backends.
+// Cache is a simple interface for cached values that can be adapted to different cache backends. See LRU and LFU for
+// in-memory implementations.
 type Cache[R any] interface {
 	// Get gets and returns a cache entry along with a flag indicating if it's present.
 	Get(key string) (R, bool)
@@ -19,17 +25,63 @@ type Cache[R any] interface {
 	Set(key string, value R)
 }
 
+// Entry is a cached value or error along with the time it was stored.
+type Entry[R any] struct {
+	// Value is the cached value.
+	Value R
+	// Error is the cached error, if any, which is returned along with the Value on a cache hit.
+	Error error
+	// StoredAt is the time the entry was stored.
+	StoredAt time.Time
+	// TTL is how long the entry should be stored for, else 0 if the cache's default should be used.
+	TTL time.Duration
+}
+
+// EntryCache is a Cache that can store and return entries along with the time they were stored, which allows a
+// CachePolicy to determine the freshness of cached values, and to cache errors. LRU and LFU caches implement EntryCache.
+type EntryCache[R any] interface {
+	Cache[R]
+
+	// GetEntry gets and returns a cache entry along with a flag indicating if it's present.
+	GetEntry(key string) (Entry[R], bool)
+
+	// SetEntry stores an entry for the key in the cache. Implementations should expire the entry after its TTL, if the
+	// TTL is > 0.
+	SetEntry(key string, entry Entry[R])
+}
+
+// InvalidatingCache is a Cache that supports removing entries. LRU and LFU caches implement InvalidatingCache.
+type InvalidatingCache[R any] interface {
+	Cache[R]
+
+	// Delete removes the entry for the key, if any.
+	Delete(key string)
+
+	// Invalidate removes all entries whose keys start with the prefix.
+	Invalidate(prefix string)
+}
+
 // CachePolicy is a read through cache Policy that sets and gets cached results for some key. The cache key can be
 // configured via CachePolicyBuilder, or by setting a CacheKey value in a Context used with an execution.
 //
 // This type is concurrency safe.
 type CachePolicy[R any] interface {
 	failsafe.Policy[R]
+
+	// Delete removes the cached entry for the key, after prefixing it with the configured namespace and version. Does
+	// nothing if the cache does not implement InvalidatingCache.
+	Delete(key string)
+
+	// Invalidate removes all cached entries whose keys start with the prefix, after prefixing it with the configured
+	// namespace and version. An empty prefix removes all entries for the configured namespace and version. Does nothing if
+	// the cache does not implement InvalidatingCache.
+	Invalidate(prefix string)
 }
 
 // CachePolicyBuilder builds CachePolicy instances. In order for the cache policy to be used, a key must be provided via
-// WithKey, or via a Context when the execution is performed using a value stored under the CacheKey in the Context. A
-// cache key stored in a Context takes precedence over a cache key configured via WithKey.
+// WithKey or WithKeyFunc, or via a Context when the execution is performed using a value stored under the CacheKey in
+// the Context. A cache key stored in a Context takes precedence over a cache key configured via WithKeyFunc, which takes
+// precedence over a cache key configured via WithKey.
 //
 // This type is not concurrency safe.
 type CachePolicyBuilder[R any] interface {
@@ -37,10 +89,47 @@ type CachePolicyBuilder[R any] interface {
 	// providing a CacheKey in a Context used with an execution.
 	WithKey(key string) CachePolicyBuilder[R]
 
+	// WithKeyFunc configures a function that returns a cache key for an execution along with whether the execution should
+	// be cached. Key funcs can use HashKey to build composite keys from multiple values. The key func takes precedence over
+	// WithKey, and can be overridden by providing a CacheKey in a Context used with an execution.
+	WithKeyFunc(keyFunc func(exec failsafe.Execution[R]) (string, bool)) CachePolicyBuilder[R]
+
+	// WithNamespace configures a namespace to prefix all cache keys with, in the form "namespace:version:key", to prevent
+	// keys for different services or tenants that share a cache from colliding. The version is empty if not configured,
+	// such as "users::key". Keys are prefixed in this form even without a namespace or version, such as "::key", so that
+	// they cannot collide with keys that have one. Panics if the namespace contains ':'.
+	WithNamespace(namespace string) CachePolicyBuilder[R]
+
+	// WithVersion configures a version to prefix all cache keys with, after any namespace, in the form
+	// "namespace:version:key". Changing the version prevents values cached by a previous version from being used, such as
+	// when the type of cached values changes. Panics if the version contains ':'.
+	WithVersion(version string) CachePolicyBuilder[R]
+
 	// CacheIf specifies that a value result should only be cached if it satisfies the predicate. By default, any non-error
 	// results will be cached.
 	CacheIf(predicate func(R, error) bool) CachePolicyBuilder[R]
 
+	// CacheErrorsIf enables negative caching, where errors that satisfy the predicate are cached for the ttl, and returned
+	// on a cache hit until they expire. This is useful for avoiding repeated executions for errors that are expected to
+	// persist for some time, such as not found errors. Negative caching requires a cache that implements EntryCache.
+	CacheErrorsIf(predicate func(error) bool, ttl time.Duration) CachePolicyBuilder[R]
+
+	// WithFreshness configures how long cached values are considered fresh. Fresh values are returned from the cache,
+	// while stale values are only returned according to WithStaleWhileRevalidate and WithStaleIfError, and are otherwise
+	// treated as a cache miss. Freshness requires a cache that implements EntryCache. By default, cached values are always
+	// considered fresh.
+	WithFreshness(ttl time.Duration) CachePolicyBuilder[R]
+
+	// WithStaleWhileRevalidate configures a window after a cached value becomes stale, during which the stale value is
+	// returned while the execution is performed in the background to refresh the cache. Only one refresh is performed at
+	// a time for a key. Requires WithFreshness.
+	WithStaleWhileRevalidate(window time.Duration) CachePolicyBuilder[R]
+
+	// WithStaleIfError configures a window after a cached value becomes stale, during which the stale value is returned if
+	// the execution fails with an error. When also configured with WithStaleWhileRevalidate, this applies to stale values
+	// outside the stale-while-revalidate window. Requires WithFreshness.
+	WithStaleIfError(window time.Duration) CachePolicyBuilder[R]
+
 	// OnCacheHit registers the listener to be called when the cachePolicy entry is hit during an execution.
 	OnCacheHit(listener func(event failsafe.ExecutionDoneEvent[R])) CachePolicyBuilder[R]
 
@@ -55,18 +144,29 @@ type CachePolicyBuilder[R any] interface {
 }
 
 type cachePolicyConfig[R any] struct {
-	cache           Cache[R]
-	key             string
-	cacheConditions []func(result R, err error) bool
-	onHit           func(event failsafe.ExecutionDoneEvent[R])
-	onMiss          func(failsafe.ExecutionEvent[R])
-	onCache         func(failsafe.ExecutionEvent[R])
+	clock                util.Clock
+	cache                Cache[R]
+	key                  string
+	keyFunc              func(exec failsafe.Execution[R]) (string, bool)
+	namespace            string
+	version              string
+	freshness            time.Duration
+	staleWhileRevalidate time.Duration
+	staleIfError         time.Duration
+	cacheConditions      []func(result R, err error) bool
+	errorCondition       func(error) bool
+	errorTTL             time.Duration
+	onHit                func(event failsafe.ExecutionDoneEvent[R])
+	onMiss               func(failsafe.ExecutionEvent[R])
+	onCache              func(failsafe.ExecutionEvent[R])
 }
 
 var _ CachePolicyBuilder[any] = &cachePolicyConfig[any]{}
 
 type cachePolicy[R any] struct {
-	config *cachePolicyConfig[R]
+	config       *cachePolicyConfig[R]
+	keyPrefix    string
+	revalidating sync.Map // Keys that are being refreshed in the background
 }
 
 // With returns a new CachePolicy. The resulting CachePolicy will only be used with executions that provide a Context
@@ -78,6 +178,7 @@ func With[R any](cache Cache[R]) CachePolicy[R] {
 // Builder returns a CachePolicyBuilder.
 func Builder[R any](cache Cache[R]) CachePolicyBuilder[R] {
 	return &cachePolicyConfig[R]{
+		clock: util.NewClock(),
 		cache: cache,
 	}
 }
@@ -87,11 +188,49 @@ func (c *cachePolicyConfig[R]) CacheIf(predicate func(R, error) bool) CachePolic
 	return c
 }
 
+func (c *cachePolicyConfig[R]) CacheErrorsIf(predicate func(error) bool, ttl time.Duration) CachePolicyBuilder[R] {
+	c.errorCondition = predicate
+	c.errorTTL = ttl
+	return c
+}
+
 func (c *cachePolicyConfig[R]) WithKey(key string) CachePolicyBuilder[R] {
 	c.key = key
 	return c
 }
 
+func (c *cachePolicyConfig[R]) WithKeyFunc(keyFunc func(exec failsafe.Execution[R]) (string, bool)) CachePolicyBuilder[R] {
+	c.keyFunc = keyFunc
+	return c
+}
+
+func (c *cachePolicyConfig[R]) WithNamespace(namespace string) CachePolicyBuilder[R] {
+	util.Assert(!strings.Contains(namespace, ":"), "namespace must not contain ':'")
+	c.namespace = namespace
+	return c
+}
+
+func (c *cachePolicyConfig[R]) WithVersion(version string) CachePolicyBuilder[R] {
+	util.Assert(!strings.Contains(version, ":"), "version must not contain ':'")
+	c.version = version
+	return c
+}
+
+func (c *cachePolicyConfig[R]) WithFreshness(ttl time.Duration) CachePolicyBuilder[R] {
+	c.freshness = ttl
+	return c
+}
+
+func (c *cachePolicyConfig[R]) WithStaleWhileRevalidate(window time.Duration) CachePolicyBuilder[R] {
+	c.staleWhileRevalidate = window
+	return c
+}
+
+func (c *cachePolicyConfig[R]) WithStaleIfError(window time.Duration) CachePolicyBuilder[R] {
+	c.staleIfError = window
+	return c
+}
+
 func (c *cachePolicyConfig[R]) OnCacheHit(listener func(event failsafe.ExecutionDoneEvent[R])) CachePolicyBuilder[R] {
 	c.onHit = listener
 	return c
@@ -109,7 +248,20 @@ func (c *cachePolicyConfig[R]) OnResultCached(listener func(event failsafe.Execu
 
 func (c *cachePolicyConfig[R]) Build() CachePolicy[R] {
 	return &cachePolicy[R]{
-		config: c, // TODO copy base fields
+		config:    c, // TODO copy base fields
+		keyPrefix: keyPrefix(c.namespace, c.version),
+	}
+}
+
+func (c *cachePolicy[R]) Delete(key string) {
+	if cache, ok := c.config.cache.(InvalidatingCache[R]); ok {
+		cache.Delete(c.keyPrefix + key)
+	}
+}
+
+func (c *cachePolicy[R]) Invalidate(prefix string) {
+	if cache, ok := c.config.cache.(InvalidatingCache[R]); ok {
+		cache.Invalidate(c.keyPrefix + prefix)
 	}
 }
 
@@ -1,7 +1,7 @@
 package cachepolicy
 
 import (
-	"context"
+	"time"
 
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/common"
@@ -17,54 +17,174 @@ type cacheExecutor[R any] struct {
 
 var _ policy.Executor[any] = &cacheExecutor[any]{}
 
-func (e *cacheExecutor[R]) PreExecute(exec policy.ExecutionInternal[R]) *common.PolicyResult[R] {
-	execInternal := exec.(policy.ExecutionInternal[R])
-	if cacheKey := e.getCacheKey(exec.Context()); cacheKey != "" {
-		if cacheResult, found := e.config.cache.Get(cacheKey); found {
-			if e.config.onHit != nil {
-				e.config.onHit(failsafe.ExecutionDoneEvent[R]{
-					ExecutionStats: execInternal,
-					Result:         cacheResult,
-				})
-			}
-			return &common.PolicyResult[R]{
-				Result:     cacheResult,
-				Done:       true,
-				Success:    true,
-				SuccessAll: true,
+// freshness describes the state of a cached entry.
+type freshness int
+
+const (
+	fresh freshness = iota
+	staleWhileRevalidate
+	staleIfError
+	expired
+)
+
+func (e *cacheExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+		cacheKey := e.getCacheKey(exec)
+		var staleEntry *Entry[R]
+		if cacheKey != "" {
+			if entry, found := e.getEntry(cacheKey); found {
+				switch e.freshnessOf(entry) {
+				case fresh:
+					return e.hit(execInternal, entry)
+				case staleWhileRevalidate:
+					e.revalidate(execInternal, cacheKey, innerFn)
+					return e.hit(execInternal, entry)
+				case staleIfError:
+					staleEntry = &entry
+				}
 			}
 		}
+
+		e.RecordEvent(execInternal, failsafe.PolicyEvent{Type: failsafe.CacheMiss, Policy: "CachePolicy", Source: e.cachePolicy})
+		if e.config.onMiss != nil {
+			e.config.onMiss(failsafe.ExecutionEvent[R]{
+				ExecutionAttempt: execInternal,
+			})
+		}
+		er := innerFn(exec)
+		if staleEntry != nil && er.Error != nil {
+			return e.hit(execInternal, *staleEntry)
+		}
+		if cacheKey != "" {
+			e.cacheResult(execInternal, cacheKey, er)
+		}
+		return er
 	}
-	if e.config.onMiss != nil {
-		e.config.onMiss(failsafe.ExecutionEvent[R]{
-			ExecutionAttempt: execInternal,
-		})
-	}
-	return nil
 }
 
-func (e *cacheExecutor[R]) PostExecute(exec policy.ExecutionInternal[R], er *common.PolicyResult[R]) *common.PolicyResult[R] {
+// cacheResult caches the result for the cacheKey if it should be cached.
+func (e *cacheExecutor[R]) cacheResult(exec policy.ExecutionInternal[R], cacheKey string, er *common.PolicyResult[R]) {
+	entry := Entry[R]{
+		Value: er.Result,
+		Error: er.Error,
+	}
 	shouldCache := (len(e.config.cacheConditions) == 0 && er.Error == nil) ||
 		util.AppliesToAny(e.config.cacheConditions, er.Result, er.Error)
+	if !shouldCache && er.Error != nil && e.config.errorCondition != nil && e.config.errorCondition(er.Error) {
+		if _, ok := e.config.cache.(EntryCache[R]); ok {
+			entry.TTL = e.config.errorTTL
+			shouldCache = true
+		}
+	}
 
 	if shouldCache {
-		if cacheKey := e.getCacheKey(exec.Context()); cacheKey != "" {
-			e.config.cache.Set(cacheKey, er.Result)
-			if e.config.onCache != nil {
-				e.config.onCache(failsafe.ExecutionEvent[R]{
-					ExecutionAttempt: exec.CopyWithResult(er),
-				})
-			}
+		e.setEntry(cacheKey, entry)
+		if e.config.onCache != nil {
+			e.config.onCache(failsafe.ExecutionEvent[R]{
+				ExecutionAttempt: exec.CopyWithResult(er),
+			})
 		}
 	}
-	return er
 }
 
-func (e *cacheExecutor[R]) getCacheKey(ctx context.Context) string {
-	if untypedKey := ctx.Value(CacheKey); untypedKey != nil {
-		if typedKey, ok := untypedKey.(string); ok {
-			return typedKey
+func (e *cacheExecutor[R]) hit(exec policy.ExecutionInternal[R], entry Entry[R]) *common.PolicyResult[R] {
+	e.RecordEvent(exec, failsafe.PolicyEvent{Type: failsafe.CacheHit, Policy: "CachePolicy", Source: e.cachePolicy})
+	if e.config.onHit != nil {
+		e.config.onHit(failsafe.ExecutionDoneEvent[R]{
+			ExecutionStats: exec,
+			Result:         entry.Value,
+			Error:          entry.Error,
+		})
+	}
+	success := entry.Error == nil
+	return &common.PolicyResult[R]{
+		Result:     entry.Value,
+		Error:      entry.Error,
+		Done:       true,
+		Success:    success,
+		SuccessAll: success,
+	}
+}
+
+// revalidate performs the innerFn in the background to refresh the cached value for the cacheKey, unless a refresh is
+// already in progress for the cacheKey.
+func (e *cacheExecutor[R]) revalidate(exec policy.ExecutionInternal[R], cacheKey string, innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) {
+	if _, loaded := e.revalidating.LoadOrStore(cacheKey, struct{}{}); loaded {
+		return
+	}
+	bgExec := exec.CopyForBackground().(policy.ExecutionInternal[R])
+	go func() {
+		defer e.revalidating.Delete(cacheKey)
+		defer bgExec.Cancel(nil)
+		e.cacheResult(bgExec, cacheKey, innerFn(bgExec))
+	}()
+}
+
+func (e *cacheExecutor[R]) getEntry(cacheKey string) (Entry[R], bool) {
+	if entryCache, ok := e.config.cache.(EntryCache[R]); ok {
+		return entryCache.GetEntry(cacheKey)
+	}
+	value, found := e.config.cache.Get(cacheKey)
+	return Entry[R]{Value: value}, found
+}
+
+// setEntry stores the entry, which only includes the entry's value if the cache is not an EntryCache.
+func (e *cacheExecutor[R]) setEntry(cacheKey string, entry Entry[R]) {
+	if entryCache, ok := e.config.cache.(EntryCache[R]); ok {
+		entry.StoredAt = time.Unix(0, e.config.clock.CurrentUnixNano())
+		entryCache.SetEntry(cacheKey, entry)
+		return
+	}
+	e.config.cache.Set(cacheKey, entry.Value)
+}
+
+// freshnessOf returns the freshness of the entry. Entries without a stored time are always fresh. Entries with an error
+// are never stale, and expire after their TTL or freshness.
+func (e *cacheExecutor[R]) freshnessOf(entry Entry[R]) freshness {
+	if entry.StoredAt.IsZero() {
+		return fresh
+	}
+	age := time.Duration(e.config.clock.CurrentUnixNano() - entry.StoredAt.UnixNano())
+	if entry.TTL > 0 && age >= entry.TTL {
+		return expired
+	}
+	if e.config.freshness <= 0 {
+		return fresh
+	}
+	stale := age - e.config.freshness
+	switch {
+	case stale < 0:
+		return fresh
+	case entry.Error != nil:
+		return expired
+	case stale < e.config.staleWhileRevalidate:
+		return staleWhileRevalidate
+	case stale < e.config.staleIfError:
+		return staleIfError
+	default:
+		return expired
+	}
+}
+
+// getCacheKey returns the prefixed cache key for the exec, else an empty string if the exec should not be cached.
+func (e *cacheExecutor[R]) getCacheKey(exec failsafe.Execution[R]) string {
+	cacheKey := e.config.key
+	switch typedKey := exec.Context().Value(CacheKey).(type) {
+	case string:
+		cacheKey = typedKey
+	case Keyer:
+		cacheKey = typedKey.CacheKey()
+	default:
+		if e.config.keyFunc != nil {
+			var ok bool
+			if cacheKey, ok = e.config.keyFunc(exec); !ok {
+				return ""
+			}
 		}
 	}
-	return e.config.key
+	if cacheKey == "" {
+		return ""
+	}
+	return e.keyPrefix + cacheKey
 }
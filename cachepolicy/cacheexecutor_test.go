@@ -0,0 +1,225 @@
+package cachepolicy
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+func TestShouldExpireStaleEntries(t *testing.T) {
+	// Given
+	clock := &testutil.TestClock{}
+	cp := cachePolicyWithClock(Builder[string](LRU[string](10)).
+		WithKey("foo").
+		WithFreshness(time.Second), clock)
+	failsafe.Get(func() (string, error) { return "bar", nil }, cp)
+
+	// When / Then
+	clock.CurrentTime = testutil.MillisToNanos(999)
+	result, _ := failsafe.Get(func() (string, error) { return "baz", nil }, cp)
+	assert.Equal(t, "bar", result)
+
+	clock.CurrentTime = testutil.MillisToNanos(1000)
+	result, _ = failsafe.Get(func() (string, error) { return "baz", nil }, cp)
+	assert.Equal(t, "baz", result)
+}
+
+func TestShouldReturnStaleWhileRevalidating(t *testing.T) {
+	// Given
+	clock := &testutil.TestClock{}
+	cp := cachePolicyWithClock(Builder[string](LRU[string](10)).
+		WithKey("foo").
+		WithFreshness(time.Second).
+		WithStaleWhileRevalidate(time.Second), clock)
+	failsafe.Get(func() (string, error) { return "bar", nil }, cp)
+
+	// When
+	clock.CurrentTime = testutil.MillisToNanos(1500)
+	revalidated := make(chan struct{})
+	result, _ := failsafe.Get(func() (string, error) {
+		defer close(revalidated)
+		return "baz", nil
+	}, cp)
+
+	// Then
+	assert.Equal(t, "bar", result)
+	<-revalidated
+	assert.Eventually(t, func() bool {
+		result, _ = failsafe.Get(func() (string, error) { return "qux", nil }, cp)
+		return result == "baz"
+	}, time.Second, 10*time.Millisecond)
+}
+
+// Asserts that the context of a revalidation is canceled once the revalidation is done.
+func TestShouldCancelRevalidationContext(t *testing.T) {
+	// Given
+	clock := &testutil.TestClock{}
+	cp := cachePolicyWithClock(Builder[string](LRU[string](10)).
+		WithKey("foo").
+		WithFreshness(time.Second).
+		WithStaleWhileRevalidate(time.Second), clock)
+	failsafe.Get(func() (string, error) { return "bar", nil }, cp)
+
+	// When
+	clock.CurrentTime = testutil.MillisToNanos(1500)
+	revalidationCtx := make(chan context.Context, 1)
+	failsafe.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		revalidationCtx <- exec.Context()
+		return "baz", nil
+	}, cp)
+
+	// Then
+	ctx := <-revalidationCtx
+	assert.Eventually(t, func() bool {
+		return ctx.Err() != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestShouldOnlyRevalidateOnce(t *testing.T) {
+	// Given
+	clock := &testutil.TestClock{}
+	cp := cachePolicyWithClock(Builder[string](LRU[string](10)).
+		WithKey("foo").
+		WithFreshness(time.Second).
+		WithStaleWhileRevalidate(time.Second), clock)
+	failsafe.Get(func() (string, error) { return "bar", nil }, cp)
+	clock.CurrentTime = testutil.MillisToNanos(1500)
+	revalidating := make(chan struct{})
+	defer close(revalidating)
+
+	// When
+	revalidations := atomic.Int32{}
+	for i := 0; i < 3; i++ {
+		result, _ := failsafe.Get(func() (string, error) {
+			revalidations.Add(1)
+			<-revalidating
+			return "baz", nil
+		}, cp)
+		assert.Equal(t, "bar", result)
+	}
+
+	// Then
+	assert.Eventually(t, func() bool {
+		return revalidations.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestShouldReturnStaleIfError(t *testing.T) {
+	// Given
+	clock := &testutil.TestClock{}
+	cp := cachePolicyWithClock(Builder[string](LRU[string](10)).
+		WithKey("foo").
+		WithFreshness(time.Second).
+		WithStaleIfError(time.Second), clock)
+	failsafe.Get(func() (string, error) { return "bar", nil }, cp)
+	err := errors.New("test")
+
+	// When / Then
+	clock.CurrentTime = testutil.MillisToNanos(1500)
+	result, resultErr := failsafe.Get(func() (string, error) { return "", err }, cp)
+	assert.Equal(t, "bar", result)
+	assert.Nil(t, resultErr)
+
+	clock.CurrentTime = testutil.MillisToNanos(2000)
+	result, resultErr = failsafe.Get(func() (string, error) { return "", err }, cp)
+	assert.Equal(t, "", result)
+	assert.ErrorIs(t, resultErr, err)
+
+	result, _ = failsafe.Get(func() (string, error) { return "baz", nil }, cp)
+	assert.Equal(t, "baz", result)
+}
+
+func TestShouldCacheErrors(t *testing.T) {
+	// Given
+	clock := &testutil.TestClock{}
+	cp := cachePolicyWithClock(Builder[string](LRU[string](10)).
+		WithKey("foo").
+		CacheErrorsIf(func(err error) bool {
+			return errors.Is(err, testutil.ErrInvalidState)
+		}, time.Second), clock)
+	executions := 0
+	fn := func() (string, error) {
+		executions++
+		return "", testutil.ErrInvalidState
+	}
+	failsafe.Get(fn, cp)
+
+	// When / Then
+	clock.CurrentTime = testutil.MillisToNanos(999)
+	_, err := failsafe.Get(fn, cp)
+	assert.ErrorIs(t, err, testutil.ErrInvalidState)
+	assert.Equal(t, 1, executions)
+
+	clock.CurrentTime = testutil.MillisToNanos(1000)
+	result, err := failsafe.Get(func() (string, error) { return "bar", nil }, cp)
+	assert.Equal(t, "bar", result)
+	assert.Nil(t, err)
+}
+
+func TestShouldNotCacheErrorsThatDoNotMatch(t *testing.T) {
+	// Given
+	cp := Builder[string](LRU[string](10)).
+		WithKey("foo").
+		CacheErrorsIf(func(err error) bool {
+			return errors.Is(err, testutil.ErrInvalidState)
+		}, time.Second).
+		Build()
+	failsafe.Get(func() (string, error) { return "", testutil.ErrConnecting }, cp)
+
+	// When
+	result, err := failsafe.Get(func() (string, error) { return "bar", nil }, cp)
+
+	// Then
+	assert.Equal(t, "bar", result)
+	assert.Nil(t, err)
+}
+
+// Tests that errors that are cached via CacheIf are returned on a cache hit.
+func TestShouldReplayErrorsCachedViaCacheIf(t *testing.T) {
+	// Given
+	cp := Builder[string](LRU[string](10)).
+		WithKey("foo").
+		CacheIf(func(s string, err error) bool {
+			return true
+		}).
+		Build()
+	failsafe.Get(func() (string, error) { return "", testutil.ErrInvalidState }, cp)
+
+	// When
+	_, err := failsafe.Get(func() (string, error) { return "bar", nil }, cp)
+
+	// Then
+	assert.ErrorIs(t, err, testutil.ErrInvalidState)
+}
+
+func TestDeleteAndInvalidate(t *testing.T) {
+	// Given
+	cache := LRU[string](10)
+	cp := Builder[string](cache).WithNamespace("users").Build()
+	cache.Set("users::1", "a")
+	cache.Set("users::2", "b")
+	cache.Set("orders::1", "c")
+
+	// When / Then
+	cp.Delete("1")
+	assertNotCached[string](t, cache, "users::1")
+	assertCached(t, cache, "users::2", "b")
+
+	cp.Invalidate("")
+	assertNotCached[string](t, cache, "users::2")
+	assertCached(t, cache, "orders::1", "c")
+}
+
+func cachePolicyWithClock[R any](builder CachePolicyBuilder[R], clock *testutil.TestClock) CachePolicy[R] {
+	config := builder.(*cachePolicyConfig[R])
+	config.clock = clock
+	config.cache.(*memoryCache[R]).config.clock = clock
+	return builder.Build()
+}
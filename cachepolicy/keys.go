@@ -0,0 +1,33 @@
+package cachepolicy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Keyer is implemented by typed cache keys, which can be stored under the CacheKey in a Context as an alternative to a
+// string.
+type Keyer interface {
+	// CacheKey returns the string form of the cache key.
+	CacheKey() string
+}
+
+// HashKey returns a composite cache key for the parts, which is a hash of each part's type and value. Unlike joining
+// parts with a separator, distinct parts cannot produce the same key, so HashKey("a:b", "c") and HashKey("a", "b:c")
+// differ, as do HashKey(1) and HashKey("1").
+func HashKey(parts ...any) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		value := fmt.Sprint(part)
+		fmt.Fprintf(hash, "%T:%d:%s;", part, len(value), value)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// keyPrefix returns the prefix for keys with the namespace and version, in the form "namespace:version:". Keys are
+// prefixed even when neither is configured, as "::", and neither part may contain ':', so that prefixes for different
+// namespaces and versions cannot collide, including with keys that have no namespace or version.
+func keyPrefix(namespace string, version string) string {
+	return namespace + ":" + version + ":"
+}
@@ -0,0 +1,38 @@
+package cachepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashKey(t *testing.T) {
+	assert.Equal(t, HashKey("a", 1), HashKey("a", 1))
+	assert.NotEqual(t, HashKey("a:b", "c"), HashKey("a", "b:c"))
+	assert.NotEqual(t, HashKey(1), HashKey("1"))
+	assert.NotEqual(t, HashKey("a", "b"), HashKey("b", "a"))
+}
+
+func TestKeyPrefix(t *testing.T) {
+	assert.Equal(t, "::", keyPrefix("", ""))
+	assert.Equal(t, "users::", keyPrefix("users", ""))
+	assert.Equal(t, ":v2:", keyPrefix("", "v2"))
+	assert.Equal(t, "users:v2:", keyPrefix("users", "v2"))
+}
+
+// Asserts that keys for different namespaces and versions cannot collide.
+func TestKeyPrefixShouldNotCollide(t *testing.T) {
+	assert.NotEqual(t, keyPrefix("users", ""), keyPrefix("", "users"))
+	assert.NotEqual(t, keyPrefix("users", "")+"v2:1", keyPrefix("users", "v2")+"1")
+	assert.NotEqual(t, keyPrefix("", "v2")+"1", keyPrefix("", "")+"v2:1")
+	assert.NotEqual(t, keyPrefix("users", "v2")+"1", keyPrefix("", "")+"users:v2:1")
+}
+
+func TestNamespaceAndVersionShouldNotContainSeparator(t *testing.T) {
+	assert.Panics(t, func() {
+		Builder[any](LRU[any](1)).WithNamespace("a:b")
+	})
+	assert.Panics(t, func() {
+		Builder[any](LRU[any](1)).WithVersion("a:b")
+	})
+}
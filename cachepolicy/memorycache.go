@@ -0,0 +1,414 @@
+package cachepolicy
+
+import (
+	"container/heap"
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/internal/util"
+)
+
+/*
+MemoryCache is a Cache that stores entries in memory, up to a max number of entries. When the max entries are exceeded,
+entries are evicted according to an eviction policy:
+
+  - LRU caches evict the least recently used entry.
+  - LFU caches evict the least frequently used entry, and evict the least recently used of these in case of a tie.
+
+Entries can optionally expire after a time-to-live, which is configured for all entries via WithTTL, or per entry via
+WithTTLFunc or an Entry's TTL. Expired entries are removed when they're accessed or when they're selected for eviction.
+
+This type is concurrency safe.
+*/
+type MemoryCache[R any] interface {
+	EntryCache[R]
+	InvalidatingCache[R]
+
+	// Len returns the number of entries in the cache, including any expired entries that have not been removed yet.
+	Len() int
+
+	// Metrics returns metrics for the MemoryCache.
+	Metrics() MemoryCacheMetrics
+}
+
+// MemoryCacheMetrics contains counters for a MemoryCache.
+type MemoryCacheMetrics interface {
+	// Hits returns the number of Get calls that found an entry.
+	Hits() uint
+
+	// Misses returns the number of Get calls that did not find an entry, including calls that found an expired entry.
+	Misses() uint
+
+	// Evictions returns the number of entries that were evicted because the max entries were exceeded.
+	Evictions() uint
+
+	// Expirations returns the number of entries that were removed because they expired.
+	Expirations() uint
+}
+
+// MemoryCacheBuilder builds MemoryCache instances.
+//
+// This type is not concurrency safe.
+type MemoryCacheBuilder[R any] interface {
+	// WithTTL configures a time-to-live after which entries expire. By default, entries do not expire.
+	WithTTL(ttl time.Duration) MemoryCacheBuilder[R]
+
+	// WithTTLFunc configures a function that returns a time-to-live for an entry based on its value, after which the entry
+	// expires. A TTL of 0 indicates that the entry does not expire. Takes precedence over WithTTL.
+	WithTTLFunc(ttlFunc func(value R) time.Duration) MemoryCacheBuilder[R]
+
+	// Build returns a new MemoryCache using the builder's configuration.
+	Build() MemoryCache[R]
+}
+
+type evictionPolicy int
+
+const (
+	lruEviction evictionPolicy = iota
+	lfuEviction
+)
+
+type memoryCacheConfig[R any] struct {
+	clock          util.Clock
+	maxEntries     int
+	evictionPolicy evictionPolicy
+	ttl            time.Duration
+	ttlFunc        func(R) time.Duration
+}
+
+var _ MemoryCacheBuilder[any] = &memoryCacheConfig[any]{}
+
+// LRU returns a new MemoryCache for result type R that stores up to maxEntries, evicting the least recently used entries
+// when the maxEntries are exceeded. Panics if maxEntries < 1.
+func LRU[R any](maxEntries int) MemoryCache[R] {
+	return LRUBuilder[R](maxEntries).Build()
+}
+
+// LRUBuilder returns a new MemoryCacheBuilder for result type R that builds caches that store up to maxEntries, evicting
+// the least recently used entries when the maxEntries are exceeded. Panics if maxEntries < 1.
+func LRUBuilder[R any](maxEntries int) MemoryCacheBuilder[R] {
+	util.Assert(maxEntries > 0, "maxEntries must be > 0")
+	return &memoryCacheConfig[R]{
+		clock:          util.NewClock(),
+		maxEntries:     maxEntries,
+		evictionPolicy: lruEviction,
+	}
+}
+
+// LFU returns a new MemoryCache for result type R that stores up to maxEntries, evicting the least frequently used
+// entries when the maxEntries are exceeded. Panics if maxEntries < 1.
+func LFU[R any](maxEntries int) MemoryCache[R] {
+	return LFUBuilder[R](maxEntries).Build()
+}
+
+// LFUBuilder returns a new MemoryCacheBuilder for result type R that builds caches that store up to maxEntries, evicting
+// the least frequently used entries when the maxEntries are exceeded. Panics if maxEntries < 1.
+func LFUBuilder[R any](maxEntries int) MemoryCacheBuilder[R] {
+	util.Assert(maxEntries > 0, "maxEntries must be > 0")
+	return &memoryCacheConfig[R]{
+		clock:          util.NewClock(),
+		maxEntries:     maxEntries,
+		evictionPolicy: lfuEviction,
+	}
+}
+
+func (c *memoryCacheConfig[R]) WithTTL(ttl time.Duration) MemoryCacheBuilder[R] {
+	c.ttl = ttl
+	return c
+}
+
+func (c *memoryCacheConfig[R]) WithTTLFunc(ttlFunc func(value R) time.Duration) MemoryCacheBuilder[R] {
+	c.ttlFunc = ttlFunc
+	return c
+}
+
+func (c *memoryCacheConfig[R]) Build() MemoryCache[R] {
+	cCopy := *c
+	var queue evictionQueue[R]
+	if c.evictionPolicy == lfuEviction {
+		queue = &lfuQueue[R]{}
+	} else {
+		queue = &lruQueue[R]{list: list.New()}
+	}
+	return &memoryCache[R]{
+		config:  &cCopy,
+		entries: make(map[string]*cacheEntry[R]),
+		queue:   queue,
+	}
+}
+
+type memoryCache[R any] struct {
+	config *memoryCacheConfig[R]
+	mtx    sync.Mutex
+
+	// Guarded by mtx
+	entries     map[string]*cacheEntry[R]
+	queue       evictionQueue[R]
+	hits        uint
+	misses      uint
+	evictions   uint
+	expirations uint
+}
+
+var _ MemoryCache[any] = &memoryCache[any]{}
+
+type cacheEntry[R any] struct {
+	key       string
+	value     R
+	err       error
+	storedAt  time.Time
+	ttl       time.Duration
+	expiresAt int64 // The unix nano time that the entry expires at, else 0 if it doesn't expire
+
+	// Eviction state
+	element    *list.Element // LRU element
+	index      int           // LFU heap index
+	frequency  uint          // LFU access count
+	lastAccess uint64        // LFU access sequence
+}
+
+func (c *memoryCache[R]) Get(key string) (R, bool) {
+	entry, found := c.GetEntry(key)
+	return entry.Value, found
+}
+
+func (c *memoryCache[R]) GetEntry(key string) (Entry[R], bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	entry, found := c.entries[key]
+	if found && c.isExpired(entry) {
+		c.remove(entry)
+		c.expirations++
+		found = false
+	}
+	if !found {
+		c.misses++
+		return Entry[R]{}, false
+	}
+	c.hits++
+	c.queue.access(entry)
+	return Entry[R]{
+		Value:    entry.value,
+		Error:    entry.err,
+		StoredAt: entry.storedAt,
+		TTL:      entry.ttl,
+	}, true
+}
+
+func (c *memoryCache[R]) Set(key string, value R) {
+	c.SetEntry(key, Entry[R]{
+		Value:    value,
+		StoredAt: time.Unix(0, c.config.clock.CurrentUnixNano()),
+	})
+}
+
+func (c *memoryCache[R]) SetEntry(key string, newEntry Entry[R]) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	value := newEntry.Value
+	expiresAt := c.expiresAt(newEntry)
+	if entry, found := c.entries[key]; found {
+		entry.value = value
+		entry.err = newEntry.Error
+		entry.storedAt = newEntry.StoredAt
+		entry.ttl = newEntry.TTL
+		entry.expiresAt = expiresAt
+		c.queue.access(entry)
+		return
+	}
+
+	if len(c.entries) >= c.config.maxEntries {
+		victim := c.queue.victim()
+		c.remove(victim)
+		if c.isExpired(victim) {
+			c.expirations++
+		} else {
+			c.evictions++
+		}
+	}
+	entry := &cacheEntry[R]{
+		key:       key,
+		value:     value,
+		err:       newEntry.Error,
+		storedAt:  newEntry.StoredAt,
+		ttl:       newEntry.TTL,
+		expiresAt: expiresAt,
+	}
+	c.entries[key] = entry
+	c.queue.add(entry)
+}
+
+func (c *memoryCache[R]) Delete(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if entry, found := c.entries[key]; found {
+		c.remove(entry)
+	}
+}
+
+func (c *memoryCache[R]) Invalidate(prefix string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for key, entry := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(entry)
+		}
+	}
+}
+
+func (c *memoryCache[R]) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return len(c.entries)
+}
+
+func (c *memoryCache[R]) Metrics() MemoryCacheMetrics {
+	return c
+}
+
+func (c *memoryCache[R]) Hits() uint {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.hits
+}
+
+func (c *memoryCache[R]) Misses() uint {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.misses
+}
+
+func (c *memoryCache[R]) Evictions() uint {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.evictions
+}
+
+func (c *memoryCache[R]) Expirations() uint {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.expirations
+}
+
+// Requires external locking.
+func (c *memoryCache[R]) remove(entry *cacheEntry[R]) {
+	delete(c.entries, entry.key)
+	c.queue.remove(entry)
+}
+
+// Requires external locking.
+func (c *memoryCache[R]) isExpired(entry *cacheEntry[R]) bool {
+	return entry.expiresAt != 0 && c.config.clock.CurrentUnixNano() >= entry.expiresAt
+}
+
+func (c *memoryCache[R]) expiresAt(entry Entry[R]) int64 {
+	ttl := c.config.ttl
+	if entry.TTL > 0 {
+		ttl = entry.TTL
+	} else if c.config.ttlFunc != nil {
+		ttl = c.config.ttlFunc(entry.Value)
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	return c.config.clock.CurrentUnixNano() + ttl.Nanoseconds()
+}
+
+// evictionQueue orders cache entries for eviction.
+// Implementations are not concurrency safe and must be guarded externally.
+type evictionQueue[R any] interface {
+	add(entry *cacheEntry[R])
+	access(entry *cacheEntry[R])
+	remove(entry *cacheEntry[R])
+	// victim returns the next entry to evict.
+	victim() *cacheEntry[R]
+}
+
+// An evictionQueue that orders entries from most to least recently used.
+type lruQueue[R any] struct {
+	list *list.List
+}
+
+func (q *lruQueue[R]) add(entry *cacheEntry[R]) {
+	entry.element = q.list.PushFront(entry)
+}
+
+func (q *lruQueue[R]) access(entry *cacheEntry[R]) {
+	q.list.MoveToFront(entry.element)
+}
+
+func (q *lruQueue[R]) remove(entry *cacheEntry[R]) {
+	q.list.Remove(entry.element)
+}
+
+func (q *lruQueue[R]) victim() *cacheEntry[R] {
+	return q.list.Back().Value.(*cacheEntry[R])
+}
+
+// An evictionQueue that orders entries in a min heap by access frequency, then by last access.
+type lfuQueue[R any] struct {
+	entries  []*cacheEntry[R]
+	accesses uint64
+}
+
+func (q *lfuQueue[R]) add(entry *cacheEntry[R]) {
+	q.accesses++
+	entry.frequency = 1
+	entry.lastAccess = q.accesses
+	heap.Push(q, entry)
+}
+
+func (q *lfuQueue[R]) access(entry *cacheEntry[R]) {
+	q.accesses++
+	entry.frequency++
+	entry.lastAccess = q.accesses
+	heap.Fix(q, entry.index)
+}
+
+func (q *lfuQueue[R]) remove(entry *cacheEntry[R]) {
+	heap.Remove(q, entry.index)
+}
+
+func (q *lfuQueue[R]) victim() *cacheEntry[R] {
+	return q.entries[0]
+}
+
+// Implements heap.Interface.
+func (q *lfuQueue[R]) Len() int {
+	return len(q.entries)
+}
+
+// Implements heap.Interface.
+func (q *lfuQueue[R]) Less(i, j int) bool {
+	if q.entries[i].frequency != q.entries[j].frequency {
+		return q.entries[i].frequency < q.entries[j].frequency
+	}
+	return q.entries[i].lastAccess < q.entries[j].lastAccess
+}
+
+// Implements heap.Interface.
+func (q *lfuQueue[R]) Swap(i, j int) {
+	q.entries[i], q.entries[j] = q.entries[j], q.entries[i]
+	q.entries[i].index = i
+	q.entries[j].index = j
+}
+
+// Implements heap.Interface.
+func (q *lfuQueue[R]) Push(x any) {
+	entry := x.(*cacheEntry[R])
+	entry.index = len(q.entries)
+	q.entries = append(q.entries, entry)
+}
+
+// Implements heap.Interface.
+func (q *lfuQueue[R]) Pop() any {
+	last := len(q.entries) - 1
+	entry := q.entries[last]
+	q.entries[last] = nil
+	q.entries = q.entries[:last]
+	return entry
+}
@@ -0,0 +1,175 @@
+package cachepolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+func TestMemoryCacheShouldValidateMaxEntries(t *testing.T) {
+	assert.Panics(t, func() {
+		LRU[int](0)
+	})
+	assert.Panics(t, func() {
+		LFUBuilder[int](-1)
+	})
+}
+
+func TestLRUShouldEvictLeastRecentlyUsed(t *testing.T) {
+	cache := LRU[int](3)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+	cache.Get("a")
+
+	cache.Set("d", 4)
+
+	assertCached(t, cache, "a", 1)
+	assertNotCached(t, cache, "b")
+	assertCached(t, cache, "c", 3)
+	assertCached(t, cache, "d", 4)
+	assert.Equal(t, 3, cache.Len())
+	assert.Equal(t, uint(1), cache.Metrics().Evictions())
+}
+
+func TestLFUShouldEvictLeastFrequentlyUsed(t *testing.T) {
+	cache := LFU[int](3)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+	cache.Get("c")
+
+	// c and b have the same frequency, but b was used less recently
+	cache.Set("d", 4)
+	assertNotCached(t, cache, "b")
+
+	// d is the least frequently used
+	cache.Set("e", 5)
+	assertNotCached(t, cache, "d")
+	assertCached(t, cache, "a", 1)
+	assertCached(t, cache, "c", 3)
+	assertCached(t, cache, "e", 5)
+	assert.Equal(t, uint(2), cache.Metrics().Evictions())
+}
+
+func TestMemoryCacheShouldReplaceValues(t *testing.T) {
+	cache := LRU[int](2)
+	cache.Set("a", 1)
+	cache.Set("a", 2)
+
+	assertCached(t, cache, "a", 2)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestMemoryCacheWithTTL(t *testing.T) {
+	clock := &testutil.TestClock{}
+	cache := memoryCacheWithClock(LRUBuilder[int](10).WithTTL(time.Second), clock)
+	cache.Set("a", 1)
+
+	clock.CurrentTime = testutil.MillisToNanos(999)
+	assertCached(t, cache, "a", 1)
+
+	clock.CurrentTime = testutil.MillisToNanos(1000)
+	assertNotCached(t, cache, "a")
+	assert.Equal(t, 0, cache.Len())
+	assert.Equal(t, uint(1), cache.Metrics().Expirations())
+}
+
+func TestMemoryCacheWithTTLFunc(t *testing.T) {
+	clock := &testutil.TestClock{}
+	cache := memoryCacheWithClock(LFUBuilder[int](10).
+		WithTTL(time.Minute).
+		WithTTLFunc(func(value int) time.Duration {
+			return time.Duration(value) * time.Second
+		}), clock)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 0)
+
+	clock.CurrentTime = testutil.MillisToNanos(1500)
+	assertNotCached(t, cache, "a")
+	assertCached(t, cache, "b", 2)
+	assertCached(t, cache, "c", 0)
+
+	clock.CurrentTime = testutil.MillisToNanos(100000)
+	assertNotCached(t, cache, "b")
+	assertCached(t, cache, "c", 0)
+}
+
+func TestMemoryCacheWithEntryTTL(t *testing.T) {
+	clock := &testutil.TestClock{}
+	cache := memoryCacheWithClock(LRUBuilder[int](10).WithTTL(time.Minute), clock)
+	cache.SetEntry("a", Entry[int]{Error: testutil.ErrInvalidState, TTL: time.Second})
+
+	clock.CurrentTime = testutil.MillisToNanos(999)
+	entry, found := cache.GetEntry("a")
+	assert.True(t, found)
+	assert.ErrorIs(t, entry.Error, testutil.ErrInvalidState)
+
+	clock.CurrentTime = testutil.MillisToNanos(1000)
+	assertNotCached(t, cache, "a")
+}
+
+func TestMemoryCacheDeleteAndInvalidate(t *testing.T) {
+	cache := LRU[int](10)
+	cache.Set("users:1", 1)
+	cache.Set("users:2", 2)
+	cache.Set("orders:1", 3)
+
+	cache.Delete("users:1")
+	assertNotCached(t, cache, "users:1")
+	assertCached(t, cache, "users:2", 2)
+
+	cache.Invalidate("users:")
+	assertNotCached(t, cache, "users:2")
+	assertCached(t, cache, "orders:1", 3)
+	assert.Equal(t, 1, cache.Len())
+}
+
+// Asserts that expired entries that are evicted are recorded as expirations.
+func TestMemoryCacheShouldEvictExpired(t *testing.T) {
+	clock := &testutil.TestClock{}
+	cache := memoryCacheWithClock(LRUBuilder[int](1).WithTTL(time.Second), clock)
+	cache.Set("a", 1)
+	clock.CurrentTime = testutil.MillisToNanos(1000)
+
+	cache.Set("b", 2)
+
+	assert.Equal(t, uint(0), cache.Metrics().Evictions())
+	assert.Equal(t, uint(1), cache.Metrics().Expirations())
+}
+
+func TestMemoryCacheMetrics(t *testing.T) {
+	cache := LRU[int](10)
+	cache.Set("a", 1)
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+
+	metrics := cache.Metrics()
+	assert.Equal(t, uint(2), metrics.Hits())
+	assert.Equal(t, uint(1), metrics.Misses())
+	assert.Equal(t, uint(0), metrics.Evictions())
+}
+
+func memoryCacheWithClock[R any](builder MemoryCacheBuilder[R], clock *testutil.TestClock) MemoryCache[R] {
+	builder.(*memoryCacheConfig[R]).clock = clock
+	return builder.Build()
+}
+
+func assertCached[R any](t *testing.T, cache Cache[R], key string, expected R) {
+	actual, found := cache.Get(key)
+	assert.True(t, found, "expected %s to be cached", key)
+	assert.Equal(t, expected, actual)
+}
+
+func assertNotCached[R any](t *testing.T, cache Cache[R], key string) {
+	_, found := cache.Get(key)
+	assert.False(t, found, "expected %s to not be cached", key)
+}
@@ -0,0 +1,275 @@
+package chaos
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/util"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// ErrInjected is an error that can be injected by a Chaos policy.
+var ErrInjected = errors.New("chaos injected error")
+
+type key int
+
+// ScopeKey is a key to use with a Context that stores the chaos scope for an execution. See ChaosBuilder.WithScopes.
+const ScopeKey key = 0
+
+// FaultType is a type of fault injected by a Chaos policy.
+type FaultType int
+
+func (t FaultType) String() string {
+	switch t {
+	case LatencyFault:
+		return "latency"
+	case ErrorFault:
+		return "error"
+	case PanicFault:
+		return "panic"
+	case ResultFault:
+		return "result"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// LatencyFault delays an execution before it's performed.
+	LatencyFault FaultType = iota
+
+	// ErrorFault returns an error instead of performing an execution.
+	ErrorFault
+
+	// PanicFault panics instead of performing an execution.
+	PanicFault
+
+	// ResultFault returns a substitute result instead of performing an execution.
+	ResultFault
+)
+
+/*
+Chaos is a policy that injects faults into executions according to configured probabilities, in order to verify how
+other policies and the surrounding system handle them. Faults include:
+
+  - Latency, which delays an execution before it's performed, or until the execution is canceled
+  - Errors, which are returned instead of performing an execution
+  - Panics, which occur instead of performing an execution
+  - Results, which are returned instead of performing an execution
+
+Faults are evaluated in the order they're configured. Latency is injected before continuing to evaluate other faults,
+while the first error, panic, or result fault that is injected replaces the execution. Chaos policies are typically
+composed inside other policies, such as a RetryPolicy or CircuitBreaker, so that those policies handle the injected
+faults.
+
+Chaos policies can be enabled or disabled at runtime, and can be scoped to executions with particular scopes in their
+Context via ChaosBuilder.WithScopes.
+
+This type is concurrency safe.
+*/
+type Chaos[R any] interface {
+	failsafe.Policy[R]
+
+	// Enable enables fault injection.
+	Enable()
+
+	// Disable disables fault injection.
+	Disable()
+
+	// IsEnabled returns whether fault injection is enabled.
+	IsEnabled() bool
+}
+
+// FaultInjectedEvent indicates a fault was injected into an execution.
+type FaultInjectedEvent[R any] struct {
+	failsafe.ExecutionEvent[R]
+
+	// Type is the type of fault that was injected.
+	Type FaultType
+
+	// Latency is the latency that was injected, for LatencyFault.
+	Latency time.Duration
+
+	// Error is the error that was injected, for ErrorFault.
+	Error error
+
+	// PanicValue is the value that was panicked with, for PanicFault.
+	PanicValue any
+
+	// Result is the result that was injected, for ResultFault.
+	Result R
+}
+
+// ChaosBuilder builds Chaos instances.
+//
+// This type is not concurrency safe.
+type ChaosBuilder[R any] interface {
+	// InjectLatency injects the latency before an execution with the probability, which is from 0 to 1.
+	// Panics if probability is not from 0 to 1.
+	InjectLatency(probability float64, latency time.Duration) ChaosBuilder[R]
+
+	// InjectRandomLatency injects a random latency between the latencyMin and latencyMax before an execution with the
+	// probability, which is from 0 to 1.
+	// Panics if probability is not from 0 to 1 or latencyMin > latencyMax.
+	InjectRandomLatency(probability float64, latencyMin time.Duration, latencyMax time.Duration) ChaosBuilder[R]
+
+	// InjectError returns the err instead of performing an execution with the probability, which is from 0 to 1.
+	// Panics if probability is not from 0 to 1.
+	InjectError(probability float64, err error) ChaosBuilder[R]
+
+	// InjectPanic panics with the value instead of performing an execution with the probability, which is from 0 to 1.
+	// Panics if probability is not from 0 to 1.
+	InjectPanic(probability float64, value any) ChaosBuilder[R]
+
+	// InjectResult returns the result instead of performing an execution with the probability, which is from 0 to 1.
+	// Panics if probability is not from 0 to 1.
+	InjectResult(probability float64, result R) ChaosBuilder[R]
+
+	// WithScopes limits fault injection to executions whose Context contains one of the scopes under the ScopeKey. By
+	// default, faults are injected into all executions.
+	WithScopes(scopes ...string) ChaosBuilder[R]
+
+	// WithEnabled configures whether fault injection is initially enabled. Chaos policies are enabled by default.
+	WithEnabled(enabled bool) ChaosBuilder[R]
+
+	// OnFaultInjected registers the listener to be called when a fault is injected. Injected faults are also recorded as
+	// FaultInjected events with any failsafe.Observer.
+	OnFaultInjected(listener func(event FaultInjectedEvent[R])) ChaosBuilder[R]
+
+	// Build returns a new Chaos using the builder's configuration.
+	Build() Chaos[R]
+}
+
+type fault[R any] struct {
+	faultType   FaultType
+	probability float64
+	latencyMin  time.Duration
+	latencyMax  time.Duration
+	err         error
+	panicValue  any
+	result      R
+}
+
+type chaosConfig[R any] struct {
+	faults          []fault[R]
+	scopes          []string
+	enabled         bool
+	random          func() float64
+	onFaultInjected func(FaultInjectedEvent[R])
+}
+
+var _ ChaosBuilder[any] = &chaosConfig[any]{}
+
+// Builder returns a ChaosBuilder for execution result type R.
+func Builder[R any]() ChaosBuilder[R] {
+	return &chaosConfig[R]{
+		enabled: true,
+		random:  rand.Float64,
+	}
+}
+
+func (c *chaosConfig[R]) InjectLatency(probability float64, latency time.Duration) ChaosBuilder[R] {
+	return c.InjectRandomLatency(probability, latency, latency)
+}
+
+func (c *chaosConfig[R]) InjectRandomLatency(probability float64, latencyMin time.Duration, latencyMax time.Duration) ChaosBuilder[R] {
+	assertProbability(probability)
+	util.Assert(latencyMin <= latencyMax, "latencyMin must be <= latencyMax")
+	c.faults = append(c.faults, fault[R]{
+		faultType:   LatencyFault,
+		probability: probability,
+		latencyMin:  latencyMin,
+		latencyMax:  latencyMax,
+	})
+	return c
+}
+
+func (c *chaosConfig[R]) InjectError(probability float64, err error) ChaosBuilder[R] {
+	assertProbability(probability)
+	c.faults = append(c.faults, fault[R]{
+		faultType:   ErrorFault,
+		probability: probability,
+		err:         err,
+	})
+	return c
+}
+
+func (c *chaosConfig[R]) InjectPanic(probability float64, value any) ChaosBuilder[R] {
+	assertProbability(probability)
+	c.faults = append(c.faults, fault[R]{
+		faultType:   PanicFault,
+		probability: probability,
+		panicValue:  value,
+	})
+	return c
+}
+
+func (c *chaosConfig[R]) InjectResult(probability float64, result R) ChaosBuilder[R] {
+	assertProbability(probability)
+	c.faults = append(c.faults, fault[R]{
+		faultType:   ResultFault,
+		probability: probability,
+		result:      result,
+	})
+	return c
+}
+
+func assertProbability(probability float64) {
+	util.Assert(probability >= 0 && probability <= 1, "probability must be between 0 and 1")
+}
+
+func (c *chaosConfig[R]) WithScopes(scopes ...string) ChaosBuilder[R] {
+	c.scopes = scopes
+	return c
+}
+
+func (c *chaosConfig[R]) WithEnabled(enabled bool) ChaosBuilder[R] {
+	c.enabled = enabled
+	return c
+}
+
+func (c *chaosConfig[R]) OnFaultInjected(listener func(event FaultInjectedEvent[R])) ChaosBuilder[R] {
+	c.onFaultInjected = listener
+	return c
+}
+
+func (c *chaosConfig[R]) Build() Chaos[R] {
+	cCopy := *c
+	cCopy.faults = slices.Clone(c.faults)
+	cCopy.scopes = slices.Clone(c.scopes)
+	ch := &chaos[R]{
+		config: &cCopy,
+	}
+	ch.enabled.Store(c.enabled)
+	return ch
+}
+
+type chaos[R any] struct {
+	config  *chaosConfig[R]
+	enabled atomic.Bool
+}
+
+func (c *chaos[R]) Enable() {
+	c.enabled.Store(true)
+}
+
+func (c *chaos[R]) Disable() {
+	c.enabled.Store(false)
+}
+
+func (c *chaos[R]) IsEnabled() bool {
+	return c.enabled.Load()
+}
+
+func (c *chaos[R]) ToExecutor(_ R) any {
+	ce := &chaosExecutor[R]{
+		BaseExecutor: &policy.BaseExecutor[R]{},
+		chaos:        c,
+	}
+	ce.Executor = ce
+	return ce
+}
@@ -0,0 +1,90 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+func TestBuilderShouldValidateProbabilities(t *testing.T) {
+	assert.Panics(t, func() {
+		Builder[any]().InjectLatency(-.1, time.Millisecond)
+	})
+	assert.Panics(t, func() {
+		Builder[any]().InjectRandomLatency(1.1, time.Millisecond, time.Second)
+	})
+	assert.Panics(t, func() {
+		Builder[any]().InjectError(2, ErrInjected)
+	})
+	assert.Panics(t, func() {
+		Builder[any]().InjectPanic(-1, "panic")
+	})
+	assert.Panics(t, func() {
+		Builder[any]().InjectResult(1.5, nil)
+	})
+	assert.NotPanics(t, func() {
+		Builder[any]().InjectError(0, ErrInjected).InjectResult(1, nil)
+	})
+}
+
+func TestBuilderShouldValidateLatencyRange(t *testing.T) {
+	assert.Panics(t, func() {
+		Builder[any]().InjectRandomLatency(1, time.Second, time.Millisecond)
+	})
+}
+
+// Asserts that injected faults are recorded as policy events with an Executor's observers.
+func TestShouldRecordPolicyEvents(t *testing.T) {
+	// Given
+	c := Builder[any]().
+		InjectLatency(1, time.Millisecond).
+		InjectError(1, ErrInjected).
+		Build()
+	observer := &eventObserver{}
+	executor := failsafe.NewExecutor[any](c).WithObserver(observer)
+
+	// When
+	err := executor.Run(func() error {
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, ErrInjected)
+	assert.Len(t, observer.events, 2)
+	for _, event := range observer.events {
+		assert.Equal(t, failsafe.FaultInjected, event.Type)
+		assert.Equal(t, "Chaos", event.Policy)
+		assert.Equal(t, c, event.Source)
+	}
+	assert.Equal(t, time.Millisecond, observer.events[0].Delay)
+	assert.Nil(t, observer.events[0].Error)
+	assert.Equal(t, time.Duration(0), observer.events[1].Delay)
+	assert.Equal(t, ErrInjected, observer.events[1].Error)
+}
+
+// eventObserver records policy events.
+type eventObserver struct {
+	events []failsafe.PolicyEvent
+}
+
+func (o *eventObserver) ExecutionStarted(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (o *eventObserver) AttemptStarted(ctx context.Context, _ failsafe.AttemptStats) context.Context {
+	return ctx
+}
+
+func (o *eventObserver) AttemptDone(context.Context, failsafe.AttemptStats, error) {
+}
+
+func (o *eventObserver) PolicyEvent(_ context.Context, event failsafe.PolicyEvent) {
+	o.events = append(o.events, event)
+}
+
+func (o *eventObserver) ExecutionDone(context.Context, failsafe.ExecutionStats, error) {
+}
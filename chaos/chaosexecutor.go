@@ -0,0 +1,87 @@
+package chaos
+
+import (
+	"slices"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/internal/util"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// chaosExecutor is a policy.Executor that handles failures according to a Chaos policy.
+type chaosExecutor[R any] struct {
+	*policy.BaseExecutor[R]
+	*chaos[R]
+}
+
+var _ policy.Executor[any] = &chaosExecutor[any]{}
+
+func (e *chaosExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		if !e.IsEnabled() || !e.inScope(exec) {
+			return innerFn(exec)
+		}
+
+		execInternal := exec.(policy.ExecutionInternal[R])
+		for _, f := range e.config.faults {
+			if e.config.random() >= f.probability {
+				continue
+			}
+
+			switch f.faultType {
+			case LatencyFault:
+				latency := util.RandomDelayInRange(f.latencyMin, f.latencyMax, e.config.random())
+				e.onFaultInjected(execInternal, FaultInjectedEvent[R]{Type: LatencyFault, Latency: latency})
+				timer := time.NewTimer(latency)
+				select {
+				case <-timer.C:
+				case <-exec.Canceled():
+					timer.Stop()
+					_, cancelResult := execInternal.IsCanceledWithResult()
+					return cancelResult
+				}
+			case ErrorFault:
+				e.onFaultInjected(execInternal, FaultInjectedEvent[R]{Type: ErrorFault, Error: f.err})
+				return e.fnResult(exec, *(new(R)), f.err)
+			case PanicFault:
+				e.onFaultInjected(execInternal, FaultInjectedEvent[R]{Type: PanicFault, PanicValue: f.panicValue})
+				panic(f.panicValue)
+			case ResultFault:
+				e.onFaultInjected(execInternal, FaultInjectedEvent[R]{Type: ResultFault, Result: f.result})
+				return e.fnResult(exec, f.result, nil)
+			}
+		}
+		return innerFn(exec)
+	}
+}
+
+// inScope returns whether the exec is in one of the configured scopes, if any.
+func (e *chaosExecutor[R]) inScope(exec failsafe.Execution[R]) bool {
+	if len(e.config.scopes) == 0 {
+		return true
+	}
+	scope, ok := exec.Context().Value(ScopeKey).(string)
+	return ok && slices.Contains(e.config.scopes, scope)
+}
+
+func (e *chaosExecutor[R]) onFaultInjected(exec policy.ExecutionInternal[R], event FaultInjectedEvent[R]) {
+	e.RecordEvent(exec, failsafe.PolicyEvent{Type: failsafe.FaultInjected, Policy: "Chaos", Source: e.chaos, Delay: event.Latency, Error: event.Error})
+	if e.config.onFaultInjected != nil {
+		event.ExecutionEvent = failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(nil)}
+		e.config.onFaultInjected(event)
+	}
+}
+
+// fnResult returns a result as if it were returned by the execution's fn.
+func (e *chaosExecutor[R]) fnResult(exec failsafe.Execution[R], result R, err error) *common.PolicyResult[R] {
+	return &common.PolicyResult[R]{
+		Result:     result,
+		Error:      err,
+		Target:     exec.Target(),
+		Done:       true,
+		Success:    true,
+		SuccessAll: true,
+	}
+}
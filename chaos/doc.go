@@ -0,0 +1,2 @@
+// Package chaos provides a Chaos policy.
+package chaos
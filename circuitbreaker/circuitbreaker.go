@@ -1,6 +1,7 @@
 package circuitbreaker
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
@@ -57,6 +58,9 @@ A circuit breaker can be count based or time based:
     of the failureThresholdingPeriod. As time progresses, statistics for old time slices are gradually discarded, which
     smoothes the calculation of success and failure rates.
 
+Executions that are discarded, such as hedges that lose to another attempt, are not recorded as successes or failures.
+Other canceled executions, such as those canceled by a Timeout or their Context, are recorded.
+
 This type is concurrency safe.
 */
 type CircuitBreaker[R any] interface {
@@ -265,6 +269,7 @@ func (cb *circuitBreaker[R]) ToExecutor(_ R) any {
 	cbe := &circuitBreakerExecutor[R]{
 		BaseExecutor: &policy.BaseExecutor[R]{
 			BaseFailurePolicy: cb.config.BaseFailurePolicy,
+			Logger:            cb.config.logger,
 		},
 		circuitBreaker: cb,
 	}
@@ -305,7 +310,27 @@ func (cb *circuitBreaker[R]) transitionTo(newState State, exec failsafe.Executio
 		if listener != nil {
 			listener(event)
 		}
+		if cb.config.logger != nil {
+			cb.logTransition(exec, event)
+		}
+	}
+}
+
+// Logs the event to the circuit breaker's logger, along with the stats for the exec, if any.
+func (cb *circuitBreaker[R]) logTransition(exec failsafe.Execution[R], event StateChangedEvent) {
+	ctx := context.Background()
+	policyEvent := failsafe.PolicyEvent{
+		Type:     failsafe.CircuitBreakerStateChanged,
+		Policy:   "CircuitBreaker",
+		Source:   cb,
+		OldState: event.OldState.String(),
+		NewState: event.NewState.String(),
+	}
+	if exec != nil {
+		ctx = exec.Context()
+		policyEvent.AttemptStats = exec.(policy.ExecutionInternal[R]).CopyWithResult(nil)
 	}
+	cb.config.logger.PolicyEvent(ctx, policyEvent)
 }
 
 // Requires external locking.
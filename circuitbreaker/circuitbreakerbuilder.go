@@ -1,6 +1,7 @@
 package circuitbreaker
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
@@ -82,6 +83,9 @@ type CircuitBreakerBuilder[R any] interface {
 	// out of the last 10 executions were successful.
 	WithSuccessThresholdRatio(successThreshold uint, successThresholdingCapacity uint) CircuitBreakerBuilder[R]
 
+	// WithLogger configures the logger to log the CircuitBreaker's events to. See failsafe.Logger.
+	WithLogger(logger *slog.Logger) CircuitBreakerBuilder[R]
+
 	// Build returns a new CircuitBreaker using the builder's configuration.
 	Build() CircuitBreaker[R]
 }
@@ -94,6 +98,7 @@ type circuitBreakerConfig[R any] struct {
 	openListener         func(StateChangedEvent)
 	halfOpenListener     func(StateChangedEvent)
 	closeListener        func(StateChangedEvent)
+	logger               *failsafe.Logger
 
 	// Failure config
 	failureThreshold            uint
@@ -131,6 +136,11 @@ func Builder[R any]() CircuitBreakerBuilder[R] {
 	}
 }
 
+func (c *circuitBreakerConfig[R]) WithLogger(logger *slog.Logger) CircuitBreakerBuilder[R] {
+	c.logger = failsafe.NewLoggerBuilder(logger).Build()
+	return c
+}
+
 func (c *circuitBreakerConfig[R]) Build() CircuitBreaker[R] {
 	breaker := &circuitBreaker[R]{
 		config: c, // TODO copy base fields
@@ -1,6 +1,7 @@
 package circuitbreaker
 
 import (
+	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/common"
 	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/policy"
@@ -14,16 +15,43 @@ type circuitBreakerExecutor[R any] struct {
 
 var _ policy.Executor[any] = &circuitBreakerExecutor[any]{}
 
-func (e *circuitBreakerExecutor[R]) PreExecute(_ policy.ExecutionInternal[R]) *common.PolicyResult[R] {
-	if !e.TryAcquirePermit() {
+func (e *circuitBreakerExecutor[R]) PreExecute(exec policy.ExecutionInternal[R]) *common.PolicyResult[R] {
+	e.mtx.Lock()
+	oldState := e.state.getState()
+	permitted := e.tryAcquirePermit()
+	newState := e.state.getState()
+	e.mtx.Unlock()
+	e.recordStateChanged(exec, oldState, newState)
+
+	if !permitted {
+		e.RecordEvent(exec, failsafe.PolicyEvent{Type: failsafe.CircuitBreakerRejected, Policy: "CircuitBreaker", Source: e.circuitBreaker, Error: ErrOpen})
 		return internal.FailureResult[R](ErrOpen)
 	}
 	return nil
 }
 
+func (e *circuitBreakerExecutor[R]) PostExecute(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
+	// Executions that were discarded, such as hedges that lost to another attempt, are not recorded
+	if exec.IsDiscarded() {
+		e.mtx.Lock()
+		e.state.releasePermit()
+		e.mtx.Unlock()
+		if e.IsFailure(result.Result, result.Error) {
+			return result.WithFailure()
+		}
+		return result.WithDone(true, true)
+	}
+	return e.BaseExecutor.PostExecute(exec, result)
+}
+
 func (e *circuitBreakerExecutor[R]) OnSuccess(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) {
 	e.BaseExecutor.OnSuccess(exec, result)
-	e.RecordSuccess()
+	e.mtx.Lock()
+	oldState := e.state.getState()
+	e.recordSuccess()
+	newState := e.state.getState()
+	e.mtx.Unlock()
+	e.recordStateChanged(exec, oldState, newState)
 }
 
 func (e *circuitBreakerExecutor[R]) OnFailure(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
@@ -31,7 +59,24 @@ func (e *circuitBreakerExecutor[R]) OnFailure(exec policy.ExecutionInternal[R],
 	exec = exec.CopyWithResult(result).(policy.ExecutionInternal[R])
 	e.BaseExecutor.OnFailure(exec, result)
 	e.mtx.Lock()
-	defer e.mtx.Unlock()
+	oldState := e.state.getState()
 	e.recordFailure(exec)
+	newState := e.state.getState()
+	e.mtx.Unlock()
+	e.recordStateChanged(exec, oldState, newState)
 	return result
 }
+
+// recordStateChanged records a CircuitBreakerStateChanged event with the execution if the oldState and newState differ.
+// The event is not logged to the CircuitBreaker's logger, since transitions are logged when they occur.
+func (e *circuitBreakerExecutor[R]) recordStateChanged(exec policy.ExecutionInternal[R], oldState State, newState State) {
+	if oldState != newState {
+		exec.RecordEvent(failsafe.PolicyEvent{
+			Type:     failsafe.CircuitBreakerStateChanged,
+			Policy:   "CircuitBreaker",
+			Source:   e.circuitBreaker,
+			OldState: oldState.String(),
+			NewState: newState.String(),
+		})
+	}
+}
@@ -13,6 +13,7 @@ type circuitState[R any] interface {
 	getStats() circuitStats
 	getRemainingDelay() time.Duration
 	tryAcquirePermit() bool
+	releasePermit()
 	checkThresholdAndReleasePermit(exec failsafe.Execution[R])
 }
 
@@ -50,6 +51,9 @@ func (s *closedState[R]) tryAcquirePermit() bool {
 	return true
 }
 
+func (s *closedState[R]) releasePermit() {
+}
+
 // Checks to see if the executions and failure thresholds have been exceeded, opening the circuit if so.
 func (s *closedState[R]) checkThresholdAndReleasePermit(exec failsafe.Execution[R]) {
 	// Execution threshold can only be set for time based thresholding
@@ -100,6 +104,9 @@ func (s *openState[R]) tryAcquirePermit() bool {
 	return false
 }
 
+func (s *openState[R]) releasePermit() {
+}
+
 func (s *openState[R]) checkThresholdAndReleasePermit(_ failsafe.Execution[R]) {
 }
 
@@ -144,6 +151,10 @@ func (s *halfOpenState[R]) tryAcquirePermit() bool {
 	return false
 }
 
+func (s *halfOpenState[R]) releasePermit() {
+	s.permittedExecutions++
+}
+
 /*
 Checks to determine if a threshold has been met and the circuit should be opened or closed.
   - If a success threshold is configured, the circuit is opened or closed based on whether the ratio was exceeded.
@@ -181,5 +192,5 @@ func (s *halfOpenState[R]) checkThresholdAndReleasePermit(exec failsafe.Executio
 	} else if failuresExceeded {
 		s.breaker.open(exec)
 	}
-	s.permittedExecutions++
+	s.releasePermit()
 }
@@ -0,0 +1,114 @@
+package coalesce
+
+import (
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// coalesceExecutor is a policy.Executor that handles failures according to a Coalescer.
+type coalesceExecutor[R any] struct {
+	*policy.BaseExecutor[R]
+	*coalescer[R]
+}
+
+var _ policy.Executor[any] = &coalesceExecutor[any]{}
+
+func (e *coalesceExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+		coalesceKey := e.getCoalesceKey(exec)
+		if coalesceKey == "" {
+			return innerFn(exec)
+		}
+
+		for joins := 1; ; joins++ {
+			f, isLeader := e.join(execInternal, coalesceKey, innerFn)
+			select {
+			case <-f.done:
+				if !isLeader && !e.config.shareErrors && f.result.Error != nil && joins == 1 {
+					continue
+				}
+				result := *f.result
+				return &result
+			case <-exec.Canceled():
+				e.leave(coalesceKey, f)
+				_, cancelResult := execInternal.IsCanceledWithResult()
+				return cancelResult
+			}
+		}
+	}
+}
+
+// join joins an in flight execution for the coalesceKey, else starts one, returning the flight and whether the caller
+// started it.
+func (e *coalesceExecutor[R]) join(exec policy.ExecutionInternal[R], coalesceKey string, innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) (*flight[R], bool) {
+	e.mtx.Lock()
+	if f, ok := e.flights[coalesceKey]; ok {
+		f.waiters++
+		f.joined++
+		e.mtx.Unlock()
+		return f, false
+	}
+
+	f := &flight[R]{
+		done:    make(chan struct{}),
+		exec:    exec.CopyForBackground().(policy.ExecutionInternal[R]),
+		waiters: 1,
+	}
+	e.flights[coalesceKey] = f
+	e.mtx.Unlock()
+
+	go func() {
+		result := innerFn(f.exec)
+		e.mtx.Lock()
+		if e.flights[coalesceKey] == f {
+			delete(e.flights, coalesceKey)
+		}
+		joined := f.joined
+		e.mtx.Unlock()
+
+		f.result = result
+		if joined > 0 && e.config.onCoalesced != nil {
+			e.config.onCoalesced(CoalescedEvent[R]{
+				ExecutionDoneEvent: failsafe.ExecutionDoneEvent[R]{
+					ExecutionStats: f.exec,
+					Result:         result.Result,
+					Error:          result.Error,
+				},
+				Key:    coalesceKey,
+				Joined: joined,
+			})
+		}
+		close(f.done)
+		f.exec.Cancel(nil)
+	}()
+	return f, true
+}
+
+// leave removes a canceled caller from the flight, canceling the flight if no callers are waiting for it anymore.
+func (e *coalesceExecutor[R]) leave(coalesceKey string, f *flight[R]) {
+	e.mtx.Lock()
+	f.waiters--
+	canceled := f.waiters == 0
+	if canceled && e.flights[coalesceKey] == f {
+		// Subsequent callers should start a new flight rather than join a canceled one
+		delete(e.flights, coalesceKey)
+	}
+	e.mtx.Unlock()
+	if canceled {
+		f.exec.Cancel(nil)
+	}
+}
+
+func (e *coalesceExecutor[R]) getCoalesceKey(exec failsafe.Execution[R]) string {
+	if untypedKey := exec.Context().Value(CoalesceKey); untypedKey != nil {
+		if typedKey, ok := untypedKey.(string); ok {
+			return typedKey
+		}
+	}
+	if e.config.keyFunc != nil {
+		return e.config.keyFunc(exec)
+	}
+	return e.config.key
+}
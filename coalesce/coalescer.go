@@ -0,0 +1,147 @@
+package coalesce
+
+import (
+	"sync"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+type key int
+
+// CoalesceKey is a key to use with a Context that stores the coalesce key.
+const CoalesceKey key = 0
+
+/*
+Coalescer is a policy that collapses concurrent executions with the same key into a single execution, whose result is
+shared with each of the callers. The first caller for a key performs the execution, and callers that arrive while the
+execution is in flight join it rather than performing their own. The coalesce key can be configured via
+CoalescerBuilder, or by setting a CoalesceKey value in a Context used with an execution. Executions without a key are
+not coalesced.
+
+Shared executions are performed in the background, with a Context that carries the values from the first caller's
+Context, but that is not canceled when any single caller is canceled. When a caller is canceled, it stops waiting for
+the shared execution, and when all callers are canceled, the shared execution is canceled.
+
+This type is concurrency safe.
+*/
+type Coalescer[R any] interface {
+	failsafe.Policy[R]
+}
+
+// CoalescedEvent indicates that a shared execution completed and its result was shared with joined callers.
+type CoalescedEvent[R any] struct {
+	failsafe.ExecutionDoneEvent[R]
+
+	// Key is the coalesce key for the shared execution.
+	Key string
+
+	// Joined is the number of callers that joined the shared execution, not including the caller that started it.
+	Joined int
+}
+
+// CoalescerBuilder builds Coalescer instances. In order for the coalescer to be used, a key must be provided via
+// WithKey or WithKeyFunc, or via a Context when the execution is performed using a value stored under the CoalesceKey in
+// the Context. A coalesce key stored in a Context takes precedence over a key configured via the builder.
+//
+// This type is not concurrency safe.
+type CoalescerBuilder[R any] interface {
+	// WithKey configures a key to coalesce executions with. This key can be overridden by providing a CoalesceKey in a
+	// Context used with an execution.
+	WithKey(key string) CoalescerBuilder[R]
+
+	// WithKeyFunc configures a function that returns a key to coalesce an execution with, else an empty string if the
+	// execution should not be coalesced. The key func takes precedence over WithKey, and can be overridden by providing a
+	// CoalesceKey in a Context used with an execution.
+	WithKeyFunc(keyFunc func(exec failsafe.Execution[R]) string) CoalescerBuilder[R]
+
+	// ShareErrors configures whether errors from a shared execution are shared with joined callers. When false, joined
+	// callers that receive an error perform another coalesced execution, whose result is returned regardless of whether
+	// it's an error. Errors are shared by default.
+	ShareErrors(shareErrors bool) CoalescerBuilder[R]
+
+	// OnCoalesced registers the listener to be called when a shared execution completes and at least one caller joined it.
+	OnCoalesced(listener func(event CoalescedEvent[R])) CoalescerBuilder[R]
+
+	// Build returns a new Coalescer using the builder's configuration.
+	Build() Coalescer[R]
+}
+
+type coalescerConfig[R any] struct {
+	key         string
+	keyFunc     func(exec failsafe.Execution[R]) string
+	shareErrors bool
+	onCoalesced func(CoalescedEvent[R])
+}
+
+var _ CoalescerBuilder[any] = &coalescerConfig[any]{}
+
+// With returns a new Coalescer for execution result type R. The resulting Coalescer will only be used with executions
+// that provide a Context containing a CoalesceKey value.
+func With[R any]() Coalescer[R] {
+	return Builder[R]().Build()
+}
+
+// Builder returns a CoalescerBuilder for execution result type R.
+func Builder[R any]() CoalescerBuilder[R] {
+	return &coalescerConfig[R]{
+		shareErrors: true,
+	}
+}
+
+func (c *coalescerConfig[R]) WithKey(key string) CoalescerBuilder[R] {
+	c.key = key
+	return c
+}
+
+func (c *coalescerConfig[R]) WithKeyFunc(keyFunc func(exec failsafe.Execution[R]) string) CoalescerBuilder[R] {
+	c.keyFunc = keyFunc
+	return c
+}
+
+func (c *coalescerConfig[R]) ShareErrors(shareErrors bool) CoalescerBuilder[R] {
+	c.shareErrors = shareErrors
+	return c
+}
+
+func (c *coalescerConfig[R]) OnCoalesced(listener func(event CoalescedEvent[R])) CoalescerBuilder[R] {
+	c.onCoalesced = listener
+	return c
+}
+
+func (c *coalescerConfig[R]) Build() Coalescer[R] {
+	cCopy := *c
+	return &coalescer[R]{
+		config:  &cCopy,
+		flights: make(map[string]*flight[R]),
+	}
+}
+
+type coalescer[R any] struct {
+	config *coalescerConfig[R]
+	mtx    sync.Mutex
+
+	// Guarded by mtx
+	flights map[string]*flight[R]
+}
+
+// flight is a shared execution for a key.
+type flight[R any] struct {
+	done   chan struct{}
+	result *common.PolicyResult[R] // Set before done is closed
+	exec   policy.ExecutionInternal[R]
+
+	// Guarded by the coalescer's mtx
+	waiters int
+	joined  int
+}
+
+func (c *coalescer[R]) ToExecutor(_ R) any {
+	ce := &coalesceExecutor[R]{
+		BaseExecutor: &policy.BaseExecutor[R]{},
+		coalescer:    c,
+	}
+	ce.Executor = ce
+	return ce
+}
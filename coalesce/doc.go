@@ -0,0 +1,2 @@
+// Package coalesce provides a Coalescer policy.
+package coalesce
@@ -5,6 +5,8 @@ package common
 type PolicyResult[R any] struct {
 	Result R
 	Error  error
+	// Target is the target that the result was produced by, if any.
+	Target string
 	// Done indicates whether an execution is done or if retries may be needed.
 	Done bool
 	// Success indicates that a failure did not occur, or the policy was successful in handling the failure/
@@ -25,6 +25,8 @@ type ExecutionDoneEvent[R any] struct {
 	Result R
 	// The execution error, else nil
 	Error error
+	// The target of the execution attempt that produced the result, when targets are configured, else an empty string
+	Target string
 }
 
 func newExecutionDoneEvent[R any](stats ExecutionStats, er *common.PolicyResult[R]) ExecutionDoneEvent[R] {
@@ -32,5 +34,6 @@ func newExecutionDoneEvent[R any](stats ExecutionStats, er *common.PolicyResult[
 		ExecutionStats: stats,
 		Result:         er.Result,
 		Error:          er.Error,
+		Target:         er.Target,
 	}
 }
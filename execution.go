@@ -2,6 +2,10 @@ package failsafe
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,6 +15,10 @@ import (
 
 // ExecutionStats contains execution stats.
 type ExecutionStats interface {
+	// ID returns a randomly generated ID for the execution, which is the same for all of its attempts, including retries
+	// and hedges. This can be used to correlate logs and other records for an execution.
+	ID() string
+
 	// Attempts returns the number of execution attempts so far, including attempts that are currently in progress and
 	// attempts that were blocked before being executed, such as by a CircuitBreaker or RateLimiter. These can include an initial
 	// execution along with retries and hedges.
@@ -33,16 +41,10 @@ type ExecutionStats interface {
 	ElapsedTime() time.Duration
 }
 
-// ExecutionAttempt contains information for an execution attempt.
-type ExecutionAttempt[R any] interface {
+// AttemptStats contains stats for an execution attempt.
+type AttemptStats interface {
 	ExecutionStats
 
-	// LastResult returns the result, if any, from the last execution attempt.
-	LastResult() R
-
-	// LastError returns the error, if any, from the last execution attempt.
-	LastError() error
-
 	// IsFirstAttempt returns true when Attempts is 1, meaning this is the first execution attempt.
 	IsFirstAttempt() bool
 
@@ -57,6 +59,22 @@ type ExecutionAttempt[R any] interface {
 
 	// ElapsedAttemptTime returns the elapsed time since the last execution attempt began.
 	ElapsedAttemptTime() time.Duration
+
+	// Target returns the target that the execution attempt should be performed against, when targets are configured via
+	// Executor.WithTargets or Executor.WithTargetPicker, else returns an empty string. Each retry or hedge attempt is
+	// given a new target, avoiding targets that were already attempted by the execution when possible.
+	Target() string
+}
+
+// ExecutionAttempt contains information for an execution attempt.
+type ExecutionAttempt[R any] interface {
+	AttemptStats
+
+	// LastResult returns the result, if any, from the last execution attempt.
+	LastResult() R
+
+	// LastError returns the error, if any, from the last execution attempt.
+	LastError() error
 }
 
 // Execution contains information about an execution.
@@ -75,6 +93,10 @@ type Execution[R any] interface {
 	Canceled() <-chan struct{}
 }
 
+// The cause of an execution's Context being canceled when the execution is discarded, such as a hedge that lost to another
+// attempt.
+var errDiscarded = errors.New("execution discarded")
+
 // A closed channel that can be used as a canceled channel where the canceled channel would have been closed before it
 // was accessed.
 var closedChan chan any
@@ -87,6 +109,7 @@ func init() {
 type execution[R any] struct {
 	// Shared state across instances
 	mtx        *sync.Mutex
+	id         *executionID
 	startTime  time.Time
 	attempts   *atomic.Uint32
 	retries    *atomic.Uint32
@@ -95,12 +118,20 @@ type execution[R any] struct {
 
 	// Partly shared cancellation state
 	ctx            context.Context
-	cancelFunc     context.CancelFunc
+	cancelFunc     context.CancelCauseFunc
 	canceledResult **common.PolicyResult[R]
 
+	// Shared target state, guarded by mtx
+	targetPicker     TargetPicker
+	attemptedTargets *[]string
+
+	// Shared observer, if any
+	observer Observer
+
 	// Per execution state
 	attemptStartTime time.Time
 	isHedge          bool
+	target           string
 	lastResult       R     // The last error that occurred, else the zero value for R.
 	lastError        error // The last error that occurred, else nil.
 }
@@ -108,6 +139,19 @@ type execution[R any] struct {
 var _ Execution[any] = &execution[any]{}
 var _ ExecutionStats = &execution[any]{}
 
+// executionID is a lazily generated execution ID.
+type executionID struct {
+	once  sync.Once
+	value string
+}
+
+func (e *execution[R]) ID() string {
+	e.id.once.Do(func() {
+		e.id.value = fmt.Sprintf("%016x", rand.Uint64())
+	})
+	return e.id.value
+}
+
 func (e *execution[R]) Attempts() int {
 	return int(e.attempts.Load())
 }
@@ -167,6 +211,10 @@ func (e *execution[_]) ElapsedAttemptTime() time.Duration {
 	return time.Since(e.attemptStartTime)
 }
 
+func (e *execution[_]) Target() string {
+	return e.target
+}
+
 func (e *execution[_]) IsCanceled() bool {
 	return e.ctx.Err() != nil
 }
@@ -192,8 +240,8 @@ func (e *execution[R]) RecordResult(result *common.PolicyResult[R]) *common.Poli
 func (e *execution[R]) InitializeRetry() *common.PolicyResult[R] {
 	// Lock to guard against a race with a Timeout canceling the execution
 	e.mtx.Lock()
-	defer e.mtx.Unlock()
 	if canceled, cancelResult := e.isCanceledWithResult(); canceled {
+		e.mtx.Unlock()
 		return cancelResult
 	}
 
@@ -202,10 +250,24 @@ func (e *execution[R]) InitializeRetry() *common.PolicyResult[R] {
 	}
 	e.attemptStartTime = time.Now()
 	*e.canceledResult = nil
+	e.mtx.Unlock()
+
+	target := e.pickTarget()
+	e.mtx.Lock()
+	e.target = target
+	e.mtx.Unlock()
 	return nil
 }
 
 func (e *execution[R]) Cancel(result *common.PolicyResult[R]) *common.PolicyResult[R] {
+	return e.cancel(result, nil)
+}
+
+func (e *execution[R]) Discard() *common.PolicyResult[R] {
+	return e.cancel(nil, errDiscarded)
+}
+
+func (e *execution[R]) cancel(result *common.PolicyResult[R], cause error) *common.PolicyResult[R] {
 	e.mtx.Lock()
 	defer e.mtx.Unlock()
 	if canceled, cancelResult := e.isCanceledWithResult(); canceled {
@@ -218,11 +280,15 @@ func (e *execution[R]) Cancel(result *common.PolicyResult[R]) *common.PolicyResu
 		e.lastError = result.Error
 	}
 	if e.cancelFunc != nil {
-		e.cancelFunc()
+		e.cancelFunc(cause)
 	}
 	return result
 }
 
+func (e *execution[R]) IsDiscarded() bool {
+	return errors.Is(context.Cause(e.ctx), errDiscarded)
+}
+
 func (e *execution[R]) IsCanceledWithResult() (bool, *common.PolicyResult[R]) {
 	e.mtx.Lock()
 	defer e.mtx.Unlock()
@@ -243,6 +309,14 @@ func (e *execution[R]) isCanceledWithResult() (bool, *common.PolicyResult[R]) {
 	return false, nil
 }
 
+func (e *execution[R]) RecordEvent(event PolicyEvent) {
+	if e.observer == nil {
+		return
+	}
+	event.AttemptStats = e.copy()
+	e.observer.PolicyEvent(e.ctx, event)
+}
+
 func (e *execution[R]) CopyWithResult(result *common.PolicyResult[R]) Execution[R] {
 	c := e.copy()
 	if result != nil {
@@ -254,7 +328,13 @@ func (e *execution[R]) CopyWithResult(result *common.PolicyResult[R]) Execution[
 
 func (e *execution[R]) CopyForCancellable() Execution[R] {
 	c := e.copy()
-	c.ctx, c.cancelFunc = context.WithCancel(c.ctx)
+	c.ctx, c.cancelFunc = context.WithCancelCause(c.ctx)
+	return c
+}
+
+func (e *execution[R]) CopyForBackground() Execution[R] {
+	c := newExecution[R](context.WithoutCancel(e.ctx), e.targetPicker, e.observer)
+	c.ctx, c.cancelFunc = context.WithCancelCause(c.ctx)
 	return c
 }
 
@@ -263,9 +343,29 @@ func (e *execution[R]) CopyForHedge() Execution[R] {
 	c.isHedge = true
 	c.attempts.Add(1)
 	c.hedges.Add(1)
+	c.target = c.pickTarget()
 	return c
 }
 
+// pickTarget picks and returns a target for a new attempt, if a targetPicker is configured. The targetPicker is called
+// without holding the lock, so that a slow targetPicker doesn't block cancellation and can use the execution's stats.
+//
+// Must not be locked externally.
+func (e *execution[R]) pickTarget() string {
+	if e.targetPicker == nil {
+		return ""
+	}
+	e.mtx.Lock()
+	avoid := slices.Clone(*e.attemptedTargets)
+	e.mtx.Unlock()
+
+	target := e.targetPicker(e, avoid)
+	e.mtx.Lock()
+	*e.attemptedTargets = append(*e.attemptedTargets, target)
+	e.mtx.Unlock()
+	return target
+}
+
 func (e *execution[R]) copy() *execution[R] {
 	e.mtx.Lock()
 	c := *e
@@ -277,23 +377,30 @@ func (e *execution[R]) record() {
 	e.executions.Add(1)
 }
 
-func newExecution[R any](ctx context.Context) *execution[R] {
+func newExecution[R any](ctx context.Context, targetPicker TargetPicker, observer Observer) *execution[R] {
 	attempts := atomic.Uint32{}
 	retries := atomic.Uint32{}
 	hedges := atomic.Uint32{}
 	executions := atomic.Uint32{}
 	attempts.Add(1)
 	var canceledResult *common.PolicyResult[R]
+	var attemptedTargets []string
 	now := time.Now()
-	return &execution[R]{
+	exec := &execution[R]{
 		ctx:              ctx,
 		mtx:              &sync.Mutex{},
+		id:               &executionID{},
 		attempts:         &attempts,
 		retries:          &retries,
 		hedges:           &hedges,
 		executions:       &executions,
 		canceledResult:   &canceledResult,
+		targetPicker:     targetPicker,
+		attemptedTargets: &attemptedTargets,
+		observer:         observer,
 		attemptStartTime: now,
 		startTime:        now,
 	}
+	exec.target = exec.pickTarget()
+	return exec
 }
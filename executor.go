@@ -2,6 +2,8 @@ package failsafe
 
 import (
 	"context"
+	"log/slog"
+	"slices"
 
 	"github.com/failsafe-go/failsafe-go/common"
 )
@@ -62,6 +64,26 @@ type Executor[R any] interface {
 	// Execution.Canceled or Execution.IsCanceled.
 	WithContext(ctx context.Context) Executor[R]
 
+	// WithTargets returns a new copy of the Executor that performs execution attempts against the targets, such as
+	// endpoints or replicas. Each attempt, including retries and hedges, receives a target via Execution.Target. Targets
+	// are rotated across executions, and targets that were already attempted by an execution, such as targets that failed
+	// or are still in progress, are avoided by later attempts of the same execution when possible.
+	WithTargets(targets ...string) Executor[R]
+
+	// WithTargetPicker returns a new copy of the Executor that performs execution attempts against targets returned by
+	// the targetPicker. Each attempt, including retries and hedges, receives a target via Execution.Target.
+	WithTargetPicker(targetPicker TargetPicker) Executor[R]
+
+	// WithObserver returns a new copy of the Executor that notifies the observer of executions, their attempts, and events
+	// from their policies. Multiple observers can be configured by calling WithObserver more than once, and are notified
+	// in the order they're configured.
+	WithObserver(observer Observer) Executor[R]
+
+	// WithLogger returns a new copy of the Executor that logs events from the policies of its executions, such as retries,
+	// timeouts, and fallbacks, as structured records via the logger, using default levels. This is equivalent to calling
+	// WithObserver with a Logger from NewLoggerBuilder, which can be used to configure levels and sampling. See Logger.
+	WithLogger(logger *slog.Logger) Executor[R]
+
 	// OnDone registers the listener to be called when an execution is done.
 	OnDone(listener func(ExecutionDoneEvent[R])) Executor[R]
 
@@ -120,11 +142,13 @@ type Executor[R any] interface {
 }
 
 type executor[R any] struct {
-	policies  []Policy[R]
-	ctx       context.Context
-	onDone    func(ExecutionDoneEvent[R])
-	onSuccess func(ExecutionDoneEvent[R])
-	onFailure func(ExecutionDoneEvent[R])
+	policies     []Policy[R]
+	ctx          context.Context
+	targetPicker TargetPicker
+	observers    observers
+	onDone       func(ExecutionDoneEvent[R])
+	onSuccess    func(ExecutionDoneEvent[R])
+	onFailure    func(ExecutionDoneEvent[R])
 }
 
 // NewExecutor creates and returns a new Executor for result type R that will handle failures according to the given
@@ -150,6 +174,26 @@ func (e *executor[R]) WithContext(ctx context.Context) Executor[R] {
 	return &c
 }
 
+func (e *executor[R]) WithTargets(targets ...string) Executor[R] {
+	return e.WithTargetPicker(roundRobin(slices.Clone(targets)))
+}
+
+func (e *executor[R]) WithTargetPicker(targetPicker TargetPicker) Executor[R] {
+	c := *e
+	c.targetPicker = targetPicker
+	return &c
+}
+
+func (e *executor[R]) WithObserver(observer Observer) Executor[R] {
+	c := *e
+	c.observers = append(slices.Clone(e.observers), observer)
+	return &c
+}
+
+func (e *executor[R]) WithLogger(logger *slog.Logger) Executor[R] {
+	return e.WithObserver(NewLoggerBuilder(logger).Build())
+}
+
 func (e *executor[R]) OnDone(listener func(ExecutionDoneEvent[R])) Executor[R] {
 	e.onDone = listener
 	return e
@@ -221,17 +265,17 @@ type policyExecutor[R any] interface {
 }
 
 func (e *executor[R]) executeSync(fn func(exec Execution[R]) (R, error), withExec bool) (R, error) {
-	er := e.execute(fn, newExecution[R](e.ctx), withExec)
+	er := e.execute(fn, newExecution[R](e.executionContext(), e.targetPicker, e.observer()), withExec)
 	return er.Result, er.Error
 }
 
 func (e *executor[R]) executeAsync(fn func(exec Execution[R]) (R, error), withExec bool) ExecutionResult[R] {
 	var cancelFunc func()
-	ctx := e.ctx
+	ctx := e.executionContext()
 	if ctx != nil {
 		ctx, cancelFunc = context.WithCancel(ctx)
 	}
-	exec := newExecution[R](ctx)
+	exec := newExecution[R](ctx, e.targetPicker, e.observer())
 	result := &executionResult[R]{
 		execution:  exec,
 		cancelFunc: cancelFunc,
@@ -246,16 +290,27 @@ func (e *executor[R]) executeAsync(fn func(exec Execution[R]) (R, error), withEx
 func (e *executor[R]) execute(fn func(exec Execution[R]) (R, error), outerExec *execution[R], withExec bool) *common.PolicyResult[R] {
 	outerFn := func(exec Execution[R]) *common.PolicyResult[R] {
 		execInternal := exec.(*execution[R])
+		var attempt *execution[R]
+		if withExec || execInternal.observer != nil {
+			// Only copy and provide an execution to the user fn if needed
+			attempt = execInternal.copy()
+		}
+		if execInternal.observer != nil {
+			attempt.ctx = execInternal.observer.AttemptStarted(attempt.ctx, attempt)
+		}
 		var execForUser Execution[R]
 		if withExec {
-			// Only copy and provide an execution to the user fn if needed
-			execForUser = execInternal.copy()
+			execForUser = attempt
 		}
 		result, err := fn(execForUser)
 		execInternal.record()
+		if execInternal.observer != nil {
+			execInternal.observer.AttemptDone(attempt.ctx, attempt, err)
+		}
 		return &common.PolicyResult[R]{
 			Result:     result,
 			Error:      err,
+			Target:     execInternal.target,
 			Done:       true,
 			Success:    true,
 			SuccessAll: true,
@@ -279,5 +334,24 @@ func (e *executor[R]) execute(fn func(exec Execution[R]) (R, error), outerExec *
 	if e.onDone != nil {
 		e.onDone(newExecutionDoneEvent(outerExec, er))
 	}
+	if outerExec.observer != nil {
+		outerExec.observer.ExecutionDone(outerExec.ctx, outerExec, er.Error)
+	}
 	return er
 }
+
+// executionContext returns the context to perform a new execution with, which is provided by any observers.
+func (e *executor[R]) executionContext() context.Context {
+	if len(e.observers) == 0 {
+		return e.ctx
+	}
+	return e.observers.ExecutionStarted(e.ctx)
+}
+
+// observer returns an Observer for the executor's observers, else nil if there are none.
+func (e *executor[R]) observer() Observer {
+	if len(e.observers) == 0 {
+		return nil
+	}
+	return e.observers
+}
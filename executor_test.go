@@ -2,6 +2,8 @@ package failsafe_test
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,6 +11,7 @@ import (
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/fallback"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/policy"
 	"github.com/failsafe-go/failsafe-go/retrypolicy"
 )
 
@@ -73,3 +76,89 @@ func TestExecutionWithNoPolicies(t *testing.T) {
 	assert.Equal(t, "test", result)
 	assert.ErrorIs(t, testutil.ErrInvalidArgument, err)
 }
+
+// Asserts that retries are performed against targets that were not already attempted, and that the target of the final
+// attempt is recorded.
+func TestWithTargets(t *testing.T) {
+	// Given
+	rp := retrypolicy.WithDefaults[string]()
+	var targets []string
+	var doneEvent failsafe.ExecutionDoneEvent[string]
+	executor := failsafe.NewExecutor[string](rp).
+		WithTargets("a", "b", "c").
+		OnDone(func(e failsafe.ExecutionDoneEvent[string]) {
+			doneEvent = e
+		})
+	fn := func(exec failsafe.Execution[string]) (string, error) {
+		targets = append(targets, exec.Target())
+		if len(targets) < 3 {
+			return "", testutil.ErrConnecting
+		}
+		return exec.Target(), nil
+	}
+
+	// When
+	result, err := executor.GetWithExecution(fn)
+
+	// Then
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, targets)
+	assert.Equal(t, targets[2], result)
+	assert.Equal(t, targets[2], doneEvent.Target)
+
+	// When
+	targets = nil
+	execResult := executor.GetWithExecutionAsync(fn)
+
+	// Then
+	result, err = execResult.Get()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, targets)
+	assert.Equal(t, targets[2], execResult.Target())
+}
+
+func TestWithTargetPicker(t *testing.T) {
+	// Given
+	rp := retrypolicy.WithDefaults[any]()
+	var avoided [][]string
+	picker := func(stats failsafe.ExecutionStats, avoid []string) string {
+		avoided = append(avoided, slices.Clone(avoid))
+		return fmt.Sprintf("target-%d", stats.Attempts())
+	}
+	var targets []string
+
+	// When
+	err := failsafe.NewExecutor[any](rp).WithTargetPicker(picker).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		targets = append(targets, exec.Target())
+		return testutil.ErrConnecting
+	})
+
+	// Then
+	assert.ErrorIs(t, err, retrypolicy.ErrExceeded)
+	assert.Equal(t, []string{"target-1", "target-2", "target-3"}, targets)
+	assert.Equal(t, [][]string{nil, {"target-1"}, {"target-1", "target-2"}}, avoided)
+}
+
+// Tests that a TargetPicker can use the execution it's picking a target for, since it's called without holding the
+// execution's lock.
+func TestWithTargetPickerUsingExecution(t *testing.T) {
+	// Given
+	rp := retrypolicy.WithDefaults[any]()
+	picker := func(stats failsafe.ExecutionStats, avoid []string) string {
+		if canceled, _ := stats.(policy.ExecutionInternal[any]).IsCanceledWithResult(); canceled {
+			return ""
+		}
+		return fmt.Sprintf("target-%d", stats.Attempts())
+	}
+	var targets []string
+
+	// When
+	err := failsafe.NewExecutor[any](rp).WithTargetPicker(picker).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		targets = append(targets, exec.Target())
+		return testutil.ErrConnecting
+	})
+
+	// Then
+	assert.ErrorIs(t, err, retrypolicy.ErrExceeded)
+	assert.Equal(t, []string{"target-1", "target-2", "target-3"}, targets)
+}
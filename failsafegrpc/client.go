@@ -0,0 +1,110 @@
+package failsafegrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+)
+
+// NewUnaryClientInterceptor returns a grpc.UnaryClientInterceptor that performs unary calls via the policies. The
+// policies are composed around calls and will handle replies of type R in reverse order. Errors returned by policies,
+// such as circuitbreaker.ErrOpen, are converted to gRPC status errors that also wrap the policy error. See StatusError.
+//
+// R must be the type of the call's reply, such as *grpc_health_v1.HealthCheckResponse, or any. Calls whose reply is not
+// an R fail with a codes.Internal status error without being performed.
+//
+// When the policies include a HedgePolicy and replies are proto messages, each attempt receives its own reply, and the
+// reply of the successful attempt is copied into the call's reply, so that concurrent attempts don't share a reply.
+// Otherwise attempts use the call's reply. Replies that are not proto messages are always shared by attempts, so a
+// HedgePolicy should not be used with them.
+func NewUnaryClientInterceptor[R any](policies ...failsafe.Policy[R]) grpc.UnaryClientInterceptor {
+	return newUnaryClientInterceptor(failsafe.NewExecutor(policies...), hasHedgePolicy(policies))
+}
+
+// NewUnaryClientInterceptorWithExecutor returns a grpc.UnaryClientInterceptor that performs unary calls via the
+// executor. The call's context is used as the execution's context. Since the executor's policies are not known, each
+// attempt receives its own reply when replies are proto messages, as with a HedgePolicy in NewUnaryClientInterceptor.
+func NewUnaryClientInterceptorWithExecutor[R any](executor failsafe.Executor[R]) grpc.UnaryClientInterceptor {
+	return newUnaryClientInterceptor(executor, true)
+}
+
+// newUnaryClientInterceptor returns a grpc.UnaryClientInterceptor that performs unary calls via the executor, giving
+// each attempt its own reply when concurrentAttempts is true.
+func newUnaryClientInterceptor[R any](executor failsafe.Executor[R], concurrentAttempts bool) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := reply.(R); !ok {
+			return status.Errorf(codes.Internal, "failsafegrpc: reply of type %T does not match the interceptor's result type", reply)
+		}
+		result, err := executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[R]) (R, error) {
+			attemptReply := reply
+			if concurrentAttempts {
+				attemptReply = newReply(reply)
+			}
+			var trailer metadata.MD
+			err := invoker(exec.Context(), method, req, attemptReply, cc, append(opts, grpc.Trailer(&trailer))...)
+			return attemptReply.(R), withPushback(err, trailer)
+		})
+		if err == nil && concurrentAttempts {
+			copyReply(reply, result)
+		}
+		return StatusError(withoutPushback(err))
+	}
+}
+
+// hasHedgePolicy returns whether any of the policies is a HedgePolicy.
+func hasHedgePolicy[R any](policies []failsafe.Policy[R]) bool {
+	for _, p := range policies {
+		if _, ok := p.(hedgepolicy.HedgePolicy[R]); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// newReply returns a new reply for an attempt, so that concurrent attempts, such as hedges, do not share a reply. Replies
+// that are not proto messages are shared by attempts.
+func newReply(reply any) any {
+	if m, ok := reply.(proto.Message); ok {
+		return m.ProtoReflect().New().Interface()
+	}
+	return reply
+}
+
+// copyReply copies the result of an execution into the reply, if the result is a different message of the same type.
+func copyReply[R any](reply any, result R) {
+	m, ok := reply.(proto.Message)
+	if !ok {
+		return
+	}
+	if r, ok := any(result).(proto.Message); ok && r != m && r.ProtoReflect().IsValid() &&
+		r.ProtoReflect().Descriptor() == m.ProtoReflect().Descriptor() {
+		proto.Reset(m)
+		proto.Merge(m, r)
+	}
+}
+
+// NewStreamClientInterceptor returns a grpc.StreamClientInterceptor that creates client streams via the policies. The
+// policies are composed around the creation of streams, and not around the messages that are sent and received on a
+// stream, so policies such as a RetryPolicy only handle errors that occur while creating a stream. Errors returned by
+// policies are converted to gRPC status errors that also wrap the policy error. See StatusError.
+func NewStreamClientInterceptor(policies ...failsafe.Policy[grpc.ClientStream]) grpc.StreamClientInterceptor {
+	return NewStreamClientInterceptorWithExecutor(failsafe.NewExecutor(policies...))
+}
+
+// NewStreamClientInterceptorWithExecutor returns a grpc.StreamClientInterceptor that creates client streams via the
+// executor. The call's context is used as the execution's context.
+func NewStreamClientInterceptorWithExecutor(executor failsafe.Executor[grpc.ClientStream]) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[grpc.ClientStream]) (grpc.ClientStream, error) {
+			return streamer(exec.Context(), desc, cc, method, opts...)
+		})
+		return stream, StatusError(err)
+	}
+}
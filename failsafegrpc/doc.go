@@ -0,0 +1,2 @@
+// Package failsafegrpc provides functions that can be used to integrate policies with gRPC.
+package failsafegrpc
@@ -0,0 +1,410 @@
+package failsafegrpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/loadshed"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+type checkResponse = *grpc_health_v1.HealthCheckResponse
+
+var serving = &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}
+
+func TestUnaryClientRetry(t *testing.T) {
+	// Given
+	server := &testServer{failures: 2, failureErr: status.Error(codes.Unavailable, "unavailable")}
+	client := setup(t, server, nil, []grpc.DialOption{
+		grpc.WithUnaryInterceptor(NewUnaryClientInterceptor[checkResponse](RetryPolicyBuilder[checkResponse]().Build())),
+	})
+
+	// When
+	resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+	assert.Equal(t, int32(3), server.calls.Load())
+}
+
+func TestUnaryClientShouldNotRetryInvalidArgument(t *testing.T) {
+	// Given
+	server := &testServer{failures: 2, failureErr: status.Error(codes.InvalidArgument, "invalid")}
+	client := setup(t, server, nil, []grpc.DialOption{
+		grpc.WithUnaryInterceptor(NewUnaryClientInterceptor[checkResponse](RetryPolicyBuilder[checkResponse]().Build())),
+	})
+
+	// When
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	// Then
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, int32(1), server.calls.Load())
+}
+
+func TestUnaryClientWithPushback(t *testing.T) {
+	// Given
+	server := &testServer{failures: 1, failureErr: status.Error(codes.Unavailable, "unavailable"), pushback: "100"}
+	client := setup(t, server, nil, []grpc.DialOption{
+		grpc.WithUnaryInterceptor(NewUnaryClientInterceptor[checkResponse](RetryPolicyBuilder[checkResponse]().Build())),
+	})
+
+	// When
+	elapsed := testutil.Timed(func() {
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		assert.NoError(t, err)
+	})
+
+	// Then
+	assert.Equal(t, int32(2), server.calls.Load())
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
+}
+
+// Tests that an invalid pushback prevents retries.
+func TestUnaryClientWithInvalidPushback(t *testing.T) {
+	// Given
+	server := &testServer{failures: 1, failureErr: status.Error(codes.Unavailable, "unavailable"), pushback: "-1"}
+	client := setup(t, server, nil, []grpc.DialOption{
+		grpc.WithUnaryInterceptor(NewUnaryClientInterceptor[checkResponse](RetryPolicyBuilder[checkResponse]().Build())),
+	})
+
+	// When
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	// Then
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(1), server.calls.Load())
+}
+
+// Tests that hedged attempts receive their own replies, and that the reply from the successful attempt is returned.
+func TestUnaryClientWithHedgePolicy(t *testing.T) {
+	// Given
+	var checks atomic.Int32
+	server := &testServer{onCheck: func() {
+		if checks.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+	}}
+	hp := hedgepolicy.WithDelay[checkResponse](20 * time.Millisecond)
+	client := setup(t, server, nil, []grpc.DialOption{
+		grpc.WithUnaryInterceptor(NewUnaryClientInterceptor[checkResponse](hp)),
+	})
+
+	// When
+	var resp checkResponse
+	var err error
+	elapsed := testutil.Timed(func() {
+		resp, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+	assert.Equal(t, int32(2), checks.Load())
+	assert.Less(t, elapsed, 200*time.Millisecond)
+}
+
+// Tests that attempts use the call's reply when there is no HedgePolicy.
+func TestUnaryClientShouldShareReplyWithoutHedgePolicy(t *testing.T) {
+	// Given
+	interceptor := NewUnaryClientInterceptor[checkResponse](RetryPolicyBuilder[checkResponse]().Build())
+	reply := &grpc_health_v1.HealthCheckResponse{}
+	var attemptReplies []any
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attemptReplies = append(attemptReplies, reply)
+		if len(attemptReplies) == 1 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		reply.(checkResponse).Status = grpc_health_v1.HealthCheckResponse_SERVING
+		return nil
+	}
+
+	// When
+	err := interceptor(context.Background(), "/test", nil, reply, nil, invoker)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, reply.Status)
+	assert.Len(t, attemptReplies, 2)
+	for _, attemptReply := range attemptReplies {
+		assert.Same(t, reply, attemptReply)
+	}
+}
+
+// Tests that calls whose reply does not match the interceptor's result type fail with an Internal status.
+func TestUnaryClientWithMismatchedReply(t *testing.T) {
+	// Given
+	interceptor := NewUnaryClientInterceptor[checkResponse]()
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return nil
+	}
+
+	// When
+	err := interceptor(context.Background(), "/test", nil, &grpc_health_v1.HealthCheckRequest{}, nil, invoker)
+
+	// Then
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, 0, calls)
+}
+
+func TestUnaryClientWithOpenCircuitBreaker(t *testing.T) {
+	// Given
+	server := &testServer{}
+	cb := circuitbreaker.WithDefaults[checkResponse]()
+	cb.Open()
+	client := setup(t, server, nil, []grpc.DialOption{
+		grpc.WithUnaryInterceptor(NewUnaryClientInterceptor[checkResponse](cb)),
+	})
+
+	// When
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	// Then
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Equal(t, int32(0), server.calls.Load())
+}
+
+func TestStreamClientWithOpenCircuitBreaker(t *testing.T) {
+	// Given
+	cb := circuitbreaker.WithDefaults[grpc.ClientStream]()
+	cb.Open()
+	client := setup(t, &testServer{}, nil, []grpc.DialOption{
+		grpc.WithStreamInterceptor(NewStreamClientInterceptor(cb)),
+	})
+
+	// When
+	_, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	// Then
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+}
+
+// Tests that a server RateLimiter rejects calls with ResourceExhausted and a pushback trailer.
+func TestUnaryServerWithRateLimiter(t *testing.T) {
+	// Given
+	rl := ratelimiter.Smooth[any](1, 2*time.Second)
+	client := setup(t, &testServer{}, []grpc.ServerOption{
+		grpc.UnaryInterceptor(NewUnaryServerInterceptor[any](rl)),
+	}, nil)
+
+	// When
+	_, err1 := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	var trailer metadata.MD
+	_, err2 := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.Trailer(&trailer))
+
+	// Then
+	assert.NoError(t, err1)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err2))
+	pushback, ok := Pushback(withPushback(err2, trailer))
+	assert.True(t, ok)
+	assert.InDelta(t, 2*time.Second, pushback, float64(100*time.Millisecond))
+}
+
+func TestUnaryServerWithBulkhead(t *testing.T) {
+	// Given
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := &testServer{onCheck: func() {
+		close(started)
+		<-release
+	}}
+	client := setup(t, server, []grpc.ServerOption{
+		grpc.UnaryInterceptor(NewUnaryServerInterceptor[any](bulkhead.With[any](1))),
+	}, nil)
+	go client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	<-started
+
+	// When
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	close(release)
+
+	// Then
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestUnaryServerWithLoadShedder(t *testing.T) {
+	// Given
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := &testServer{onCheck: func() {
+		close(started)
+		<-release
+	}}
+	client := setup(t, server, []grpc.ServerOption{
+		grpc.UnaryInterceptor(NewUnaryServerInterceptor[any](loadshed.With[any](loadshed.InFlightSignal(1)))),
+	}, nil)
+	go client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	<-started
+
+	// When
+	var trailer metadata.MD
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.Trailer(&trailer))
+	close(release)
+
+	// Then
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Empty(t, trailer.Get(PushbackKey))
+}
+
+// Tests that rejected calls do not reserve RateLimiter permits, which would starve calls that are later permitted.
+func TestUnaryServerWithRateLimiterShouldNotReservePermits(t *testing.T) {
+	// Given
+	rl := ratelimiter.Smooth[any](1, 100*time.Millisecond)
+	client := setup(t, &testServer{}, []grpc.ServerOption{
+		grpc.UnaryInterceptor(NewUnaryServerInterceptor[any](rl)),
+	}, nil)
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+
+	// When
+	for i := 0; i < 5; i++ {
+		_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	}
+	time.Sleep(120 * time.Millisecond)
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	// Then
+	assert.NoError(t, err)
+}
+
+func TestUnaryServerWithTimeout(t *testing.T) {
+	// Given
+	server := &testServer{onCheck: func() {
+		time.Sleep(200 * time.Millisecond)
+	}}
+	client := setup(t, server, []grpc.ServerOption{
+		grpc.UnaryInterceptor(NewUnaryServerInterceptor[any](timeout.With[any](50 * time.Millisecond))),
+	}, nil)
+
+	// When
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	// Then
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestStreamServerWithBulkhead(t *testing.T) {
+	// Given
+	client := setup(t, &testServer{}, []grpc.ServerOption{
+		grpc.StreamInterceptor(NewStreamServerInterceptor(bulkhead.With[any](1))),
+	}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream1, _ := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	_, err := stream1.Recv()
+	assert.NoError(t, err)
+
+	// When
+	stream2, _ := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	_, err = stream2.Recv()
+
+	// Then
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestStatusError(t *testing.T) {
+	assert.Nil(t, StatusError(nil))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(StatusError(ratelimiter.ErrExceeded)))
+	assert.Equal(t, codes.Unavailable, status.Code(StatusError(bulkhead.ErrFull)))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(StatusError(timeout.ErrExceeded)))
+	assert.Equal(t, codes.Canceled, status.Code(StatusError(context.Canceled)))
+	assert.Equal(t, testutil.ErrInvalidState, StatusError(testutil.ErrInvalidState))
+}
+
+func TestPushback(t *testing.T) {
+	err := status.Error(codes.Unavailable, "unavailable")
+	testCases := []struct {
+		name     string
+		err      error
+		expected time.Duration
+		ok       bool
+	}{
+		{"valid", withPushback(err, metadata.Pairs(PushbackKey, "250")), 250 * time.Millisecond, true},
+		{"negative", withPushback(err, metadata.Pairs(PushbackKey, "-1")), 0, false},
+		{"invalid", withPushback(err, metadata.Pairs(PushbackKey, "abc")), 0, false},
+		{"missing", withPushback(err, metadata.MD{}), 0, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, ok := Pushback(tc.err)
+			assert.Equal(t, tc.expected, delay)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+// testServer is a health server that fails Check calls with the failureErr for some number of failures before
+// succeeding.
+type testServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	failures   int32
+	failureErr error
+	pushback   string
+	onCheck    func()
+	calls      atomic.Int32
+}
+
+func (s *testServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if s.onCheck != nil {
+		s.onCheck()
+	}
+	if s.calls.Add(1) <= s.failures {
+		if s.pushback != "" {
+			grpc.SetTrailer(ctx, metadata.Pairs(PushbackKey, s.pushback))
+		}
+		return nil, s.failureErr
+	}
+	return serving, nil
+}
+
+func (s *testServer) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	if err := stream.Send(serving); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+// setup starts a server for the testServer over bufconn and returns a client for it.
+func setup(t *testing.T, server *testServer, serverOpts []grpc.ServerOption, dialOpts []grpc.DialOption) grpc_health_v1.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(serverOpts...)
+	grpc_health_v1.RegisterHealthServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+
+	dialOpts = append(dialOpts,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		grpcServer.Stop()
+	})
+	return grpc_health_v1.NewHealthClient(conn)
+}
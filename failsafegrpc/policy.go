@@ -0,0 +1,174 @@
+package failsafegrpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/adaptivethrottle"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/loadshed"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// PushbackKey is the trailer metadata key that servers use to indicate how many milliseconds a client should wait before
+// retrying a call. A value that is not a non-negative integer indicates that the client should not retry.
+const PushbackKey = "grpc-retry-pushback-ms"
+
+// RetryPolicyBuilder returns a retrypolicy.RetryPolicyBuilder that will retry calls that fail with an Unavailable,
+// ResourceExhausted, or DeadlineExceeded status up to 2 times, by default. Calls that fail with other statuses, such as
+// InvalidArgument, are not retried. If a server provides a grpc-retry-pushback-ms trailer, it will be used as a delay
+// between retries, or will prevent retries if it's not a non-negative integer. Additional handling and delay
+// configuration can be added to the resulting builder.
+func RetryPolicyBuilder[R any]() retrypolicy.RetryPolicyBuilder[R] {
+	retryHandleFunc := func(_ R, err error) bool {
+		if err == nil {
+			return false
+		}
+		if _, ok := Pushback(err); !ok && hasPushback(err) {
+			return false
+		}
+		switch status.Code(err) {
+		case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+			return true
+		default:
+			return false
+		}
+	}
+
+	return retrypolicy.Builder[R]().
+		HandleIf(retryHandleFunc).
+		WithDelayFunc(DelayFunc[R]())
+}
+
+// DelayFunc returns a failsafe.DelayFunc that delays according to a grpc-retry-pushback-ms trailer from a failed call.
+// This can be used as a delay in a RetryPolicy or a CircuitBreaker.
+func DelayFunc[R any]() failsafe.DelayFunc[R] {
+	return func(exec failsafe.ExecutionAttempt[R]) time.Duration {
+		if delay, ok := Pushback(exec.LastError()); ok {
+			return delay
+		}
+		return -1
+	}
+}
+
+// Pushback returns the delay from a grpc-retry-pushback-ms trailer that was returned along with the err from a call
+// performed via NewUnaryClientInterceptor, along with whether a valid pushback was present.
+func Pushback(err error) (time.Duration, bool) {
+	var pe *pushbackError
+	if !errors.As(err, &pe) {
+		return 0, false
+	}
+	millis, parseErr := strconv.ParseInt(pe.pushback, 10, 64)
+	if parseErr != nil || millis < 0 {
+		return 0, false
+	}
+	return time.Duration(millis) * time.Millisecond, true
+}
+
+func hasPushback(err error) bool {
+	var pe *pushbackError
+	return errors.As(err, &pe)
+}
+
+// pushbackError is an error from a call that was returned along with a grpc-retry-pushback-ms trailer. These are unwrapped
+// before being returned from an interceptor.
+type pushbackError struct {
+	err      error
+	pushback string
+}
+
+func (e *pushbackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *pushbackError) Unwrap() error {
+	return e.err
+}
+
+func (e *pushbackError) GRPCStatus() *status.Status {
+	return status.Convert(e.err)
+}
+
+// withPushback returns the err wrapped with any pushback from the trailer.
+func withPushback(err error, trailer metadata.MD) error {
+	if err == nil {
+		return nil
+	}
+	if values := trailer.Get(PushbackKey); len(values) > 0 {
+		return &pushbackError{err: err, pushback: values[0]}
+	}
+	return err
+}
+
+// withoutPushback returns the err unwrapped from any pushback.
+func withoutPushback(err error) error {
+	if pe, ok := err.(*pushbackError); ok {
+		return pe.err
+	}
+	return err
+}
+
+// StatusError returns the err as an error with a gRPC status. Errors from policies are converted to errors with a status
+// code that wrap the policy error, so that they can still be checked via errors.Is:
+//   - ratelimiter.ErrExceeded results in ResourceExhausted
+//   - bulkhead.ErrFull, circuitbreaker.ErrOpen, loadshed.ErrShed, and adaptivethrottle.ErrThrottled result in Unavailable
+//   - timeout.ErrExceeded results in DeadlineExceeded
+//
+// Context errors are converted to Canceled or DeadlineExceeded. Other errors are returned as is.
+func StatusError(err error) error {
+	var code codes.Code
+	switch {
+	case err == nil:
+		return nil
+	case isRateLimited(err):
+		code = codes.ResourceExhausted
+	case errors.Is(err, bulkhead.ErrFull), isCircuitOpen(err), errors.Is(err, loadshed.ErrShed), errors.Is(err, adaptivethrottle.ErrThrottled):
+		code = codes.Unavailable
+	case errors.Is(err, timeout.ErrExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.FromContextError(err).Err()
+	default:
+		return err
+	}
+	return &policyError{err: err, status: status.New(code, err.Error())}
+}
+
+// policyError is an error from a policy that has a gRPC status.
+type policyError struct {
+	err    error
+	status *status.Status
+}
+
+func (e *policyError) Error() string {
+	return e.status.Err().Error()
+}
+
+func (e *policyError) Unwrap() error {
+	return e.err
+}
+
+func (e *policyError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+func isRateLimited(err error) bool {
+	return errors.Is(err, ratelimiter.ErrExceeded)
+}
+
+func isCircuitOpen(err error) bool {
+	return errors.Is(err, circuitbreaker.ErrOpen)
+}
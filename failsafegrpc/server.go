@@ -0,0 +1,93 @@
+package failsafegrpc
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/rejection"
+)
+
+// serverPolicies performs server calls via an executor, and provides pushback delays for rejections.
+type serverPolicies[R any] struct {
+	executor failsafe.Executor[R]
+}
+
+func newServerPolicies[R any](policies []failsafe.Policy[R]) *serverPolicies[R] {
+	return &serverPolicies[R]{
+		executor: failsafe.NewExecutor(policies...),
+	}
+}
+
+// executorFor returns an executor for a call with the ctx, along with an observer of the call's rejections.
+func (s *serverPolicies[R]) executorFor(ctx context.Context) (failsafe.Executor[R], *rejection.Observer[R]) {
+	rejections := &rejection.Observer[R]{}
+	return s.executor.WithContext(ctx).WithObserver(rejections), rejections
+}
+
+// NewUnaryServerInterceptor returns a grpc.UnaryServerInterceptor that handles unary calls via the policies, such as a
+// Bulkhead, RateLimiter, or LoadShedder. The policies are composed around the handler and will handle responses of type
+// R in reverse order.
+//
+// Errors returned by policies are converted to gRPC status errors. See StatusError. When a RateLimiter or CircuitBreaker
+// rejects a call, a grpc-retry-pushback-ms trailer is set, based on the time until the RateLimiter expects a permit to be
+// available, or based on the CircuitBreaker's RemainingDelay. Permits are not reserved for rejected calls.
+func NewUnaryServerInterceptor[R any](policies ...failsafe.Policy[R]) grpc.UnaryServerInterceptor {
+	s := newServerPolicies(policies)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var resp any
+		executor, rejections := s.executorFor(ctx)
+		_, err := executor.GetWithExecution(func(exec failsafe.Execution[R]) (R, error) {
+			var err error
+			resp, err = handler(exec.Context(), req)
+			result, _ := resp.(R)
+			return result, err
+		})
+		if err != nil {
+			return nil, handleError(ctx, err, rejections.RetryDelay())
+		}
+		return resp, nil
+	}
+}
+
+// NewStreamServerInterceptor returns a grpc.StreamServerInterceptor that handles streaming calls via the policies, such
+// as a Bulkhead, RateLimiter, or LoadShedder. The policies are composed around the handler, which is performed with a
+// stream whose Context is the execution's context.
+//
+// Errors returned by policies are converted to gRPC status errors, and pushback trailers are set for rejections, as with
+// NewUnaryServerInterceptor.
+func NewStreamServerInterceptor(policies ...failsafe.Policy[any]) grpc.StreamServerInterceptor {
+	s := newServerPolicies(policies)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		executor, rejections := s.executorFor(ss.Context())
+		err := executor.RunWithExecution(func(exec failsafe.Execution[any]) error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: exec.Context()})
+		})
+		if err != nil {
+			return handleError(ss.Context(), err, rejections.RetryDelay())
+		}
+		return nil
+	}
+}
+
+// handleError sets a pushback trailer for rejections that require a pushback, and returns the err as a status error.
+func handleError(ctx context.Context, err error, pushback time.Duration) error {
+	if pushback > 0 {
+		grpc.SetTrailer(ctx, metadata.Pairs(PushbackKey, strconv.FormatInt(int64((pushback+time.Millisecond-1)/time.Millisecond), 10)))
+	}
+	return StatusError(err)
+}
+
+// serverStream is a grpc.ServerStream with a different Context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
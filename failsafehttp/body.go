@@ -0,0 +1,93 @@
+package failsafehttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// ErrBodyNotReplayable is returned when a request is attempted more than once, such as for a retry or hedge, but its
+// body cannot be replayed. Bodies are replayable when a request has a GetBody func, or when they can be buffered. See
+// BufferBody.
+var ErrBodyNotReplayable = errors.New("request body is not replayable")
+
+// The max number of bytes to buffer from a request body that has no GetBody func, so that it can be replayed.
+const maxBufferedBodyBytes = 1 << 20
+
+// BufferBody reads up to maxBytes of the request's body into memory and sets the request's Body and GetBody so that the
+// body can be replayed for retries and hedges. Requests that already have a GetBody func, such as those created by
+// http.NewRequest with a bytes.Buffer, bytes.Reader, or strings.Reader body, are not modified.
+//
+// If the body is larger than maxBytes, the request's Body is replaced with one that can be read once, and
+// ErrBodyNotReplayable is returned. Requests that are not buffered beforehand are buffered up to 1 MB when performed via
+// NewRoundTripper or Request.Do.
+func BufferBody(request *http.Request, maxBytes int64) error {
+	if request.Body == nil || request.Body == http.NoBody || request.GetBody != nil {
+		return nil
+	}
+	if _, ok := request.Body.(*unreplayableBody); ok {
+		return ErrBodyNotReplayable
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(request.Body, maxBytes+1))
+	if err != nil {
+		request.Body.Close()
+		return err
+	}
+	if int64(len(buf)) > maxBytes {
+		request.Body = &unreplayableBody{
+			Reader: io.MultiReader(bytes.NewReader(buf), request.Body),
+			Closer: request.Body,
+		}
+		return ErrBodyNotReplayable
+	}
+
+	request.Body.Close()
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf)), nil
+	}
+	request.Body, _ = request.GetBody()
+	return nil
+}
+
+// replayableRequest provides a request with a fresh body for each attempt.
+type replayableRequest struct {
+	request *http.Request
+	used    atomic.Bool
+}
+
+// newReplayableRequest returns a replayableRequest for a copy of the request, buffering its body if needed.
+func newReplayableRequest(request *http.Request) (*replayableRequest, error) {
+	request = request.WithContext(request.Context())
+	if err := BufferBody(request, maxBufferedBodyBytes); err != nil && !errors.Is(err, ErrBodyNotReplayable) {
+		return nil, err
+	}
+	return &replayableRequest{request: request}, nil
+}
+
+// forAttempt returns a copy of the request with the ctx and a body that has not yet been read. The first attempt uses the
+// request's original body. Returns ErrBodyNotReplayable if a body cannot be provided.
+func (r *replayableRequest) forAttempt(ctx context.Context) (*http.Request, error) {
+	request := r.request.WithContext(ctx)
+	if r.used.CompareAndSwap(false, true) || request.Body == nil || request.Body == http.NoBody {
+		return request, nil
+	}
+	if request.GetBody == nil {
+		return nil, ErrBodyNotReplayable
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	request.Body = body
+	return request, nil
+}
+
+// unreplayableBody is a request body that was too large to buffer.
+type unreplayableBody struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,124 @@
+package failsafehttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+)
+
+// Tests that a request body without a GetBody func is buffered and replayed for retries.
+func TestRetryReplaysBufferedBody(t *testing.T) {
+	// Given
+	server, bodies := bodyRecordingServer(2)
+	defer server.Close()
+	client := http.Client{Transport: NewRoundTripper(nil, RetryPolicyBuilder().Build())}
+	req, _ := http.NewRequest(http.MethodPut, server.URL, io.NopCloser(strings.NewReader("foo")))
+
+	// When
+	resp, err := client.Do(req)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []string{"foo", "foo", "foo"}, bodies())
+}
+
+// Tests that a request body is replayed via GetBody for retries.
+func TestRetryReplaysBodyWithGetBody(t *testing.T) {
+	// Given
+	server, bodies := bodyRecordingServer(1)
+	defer server.Close()
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("foo"))
+
+	// When
+	resp, err := NewRequest(req, http.DefaultClient, RetryPolicyBuilder().Build()).Do()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []string{"foo", "foo"}, bodies())
+}
+
+// Tests that hedges are each given their own body.
+func TestHedgeReplaysBody(t *testing.T) {
+	// Given
+	var mtx sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mtx.Lock()
+		bodies = append(bodies, string(body))
+		mtx.Unlock()
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+	client := http.Client{Transport: NewRoundTripper(nil, hedgepolicy.BuilderWithDelay[*http.Response](10*time.Millisecond).Build())}
+	req, _ := http.NewRequest(http.MethodPut, server.URL, io.NopCloser(strings.NewReader("foo")))
+
+	// When
+	resp, err := client.Do(req)
+
+	// Then
+	assert.NoError(t, err)
+	resp.Body.Close()
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, []string{"foo", "foo"}, bodies)
+}
+
+// Tests that a retry fails fast when a request body is too large to be buffered.
+func TestRetryWithNonReplayableBody(t *testing.T) {
+	// Given
+	server, bodies := bodyRecordingServer(1)
+	defer server.Close()
+	req, _ := http.NewRequest(http.MethodPut, server.URL, io.NopCloser(strings.NewReader("foobar")))
+	assert.ErrorIs(t, BufferBody(req, 3), ErrBodyNotReplayable)
+
+	// When
+	resp, err := NewRequest(req, http.DefaultClient, RetryPolicyBuilder().Build()).Do()
+
+	// Then
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrBodyNotReplayable)
+	assert.Equal(t, []string{"foobar"}, bodies())
+}
+
+func TestBufferBody(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPut, "", io.NopCloser(strings.NewReader("foo")))
+
+	assert.NoError(t, BufferBody(req, 3))
+	for i := 0; i < 2; i++ {
+		body, _ := req.GetBody()
+		content, _ := io.ReadAll(body)
+		assert.Equal(t, "foo", string(content))
+	}
+}
+
+// bodyRecordingServer returns a server that responds with a 503 failTimes before succeeding, along with a func that
+// returns the request bodies it received.
+func bodyRecordingServer(failTimes int) (*httptest.Server, func() []string) {
+	var mtx sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mtx.Lock()
+		defer mtx.Unlock()
+		bodies = append(bodies, string(body))
+		if len(bodies) <= failTimes {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	return server, func() []string {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return append([]string(nil), bodies...)
+	}
+}
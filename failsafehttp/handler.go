@@ -0,0 +1,155 @@
+package failsafehttp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/rejection"
+	"github.com/failsafe-go/failsafe-go/loadshed"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+type handler struct {
+	next     http.Handler
+	executor failsafe.Executor[*http.Response]
+}
+
+// NewHandler returns a new http.Handler that will serve requests via the policies and next handler. The policies are
+// composed around the next handler, and see a response containing the status code and headers that the next handler
+// wrote, which allows policies such as a CircuitBreaker to handle error responses.
+//
+// When a policy rejects or fails a request before the next handler has written a response, an error response is written
+// instead:
+//   - bulkhead.ErrFull and loadshed.ErrShed result in a 503
+//   - timeout.ErrExceeded results in a 504
+//   - circuitbreaker.ErrOpen results in a 503 with a Retry-After header based on the CircuitBreaker's RemainingDelay
+//   - ratelimiter.ErrExceeded results in a 429 with a Retry-After header based on the time until the RateLimiter that
+//     rejected the request expects a permit to be available. Permits are not reserved for rejected requests.
+//   - Any other error results in a 500
+//
+// Since the next handler writes its response directly, policies that perform additional attempts, such as a RetryPolicy
+// or HedgePolicy, should not be used.
+func NewHandler(next http.Handler, policies ...failsafe.Policy[*http.Response]) http.Handler {
+	return &handler{
+		next:     next,
+		executor: failsafe.NewExecutor(policies...),
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	writer := &responseWriter{
+		ResponseWriter: w,
+		header:         make(http.Header),
+		statusCode:     http.StatusOK,
+	}
+	rejections := &rejection.Observer[*http.Response]{}
+	_, err := h.executor.WithContext(request.Context()).WithObserver(rejections).GetWithExecution(func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
+		r := request.WithContext(exec.Context())
+		h.next.ServeHTTP(writer, r)
+		return writer.response(r), nil
+	})
+	if err != nil && !writer.wroteHeader {
+		writeError(w, err, rejections.RetryDelay())
+	}
+}
+
+// writeError writes a response for an error returned by a policy, with a Retry-After header if the retryAfter is > 0.
+func writeError(w http.ResponseWriter, err error, retryAfter time.Duration) {
+	statusCode := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ratelimiter.ErrExceeded):
+		statusCode = http.StatusTooManyRequests
+	case errors.Is(err, circuitbreaker.ErrOpen), errors.Is(err, bulkhead.ErrFull), errors.Is(err, loadshed.ErrShed):
+		statusCode = http.StatusServiceUnavailable
+	case errors.Is(err, timeout.ErrExceeded):
+		statusCode = http.StatusGatewayTimeout
+	}
+
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	http.Error(w, http.StatusText(statusCode), statusCode)
+}
+
+// responseWriter records the status code and headers written by a handler. Headers are buffered until a status code is
+// written so that headers set by a handler that fails before writing are not included in an error response. Flushing and
+// hijacking are passed through to the underlying http.ResponseWriter, and other optional interfaces are available via
+// http.ResponseController, which uses Unwrap.
+type responseWriter struct {
+	http.ResponseWriter
+	header      http.Header
+	statusCode  int
+	wroteHeader bool
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	for k, v := range w.header {
+		w.ResponseWriter.Header()[k] = v
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+	w.statusCode = statusCode
+	w.wroteHeader = true
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hijacks the connection, such as for a websocket upgrade, if the underlying http.ResponseWriter supports it. The
+// response is treated as having a 101 status code once the connection is hijacked.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("failsafehttp: %T does not implement http.Hijacker", w.ResponseWriter)
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		w.statusCode = http.StatusSwitchingProtocols
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for use by http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// response returns an http.Response containing the status code and headers that were written.
+func (w *responseWriter) response(request *http.Request) *http.Response {
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", w.statusCode, http.StatusText(w.statusCode)),
+		StatusCode: w.statusCode,
+		Header:     w.header.Clone(),
+		Request:    request,
+	}
+}
@@ -0,0 +1,179 @@
+package failsafehttp
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+func TestHandlerSuccess(t *testing.T) {
+	// Given
+	handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test", "foo")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("bar"))
+	}), bulkhead.With[*http.Response](1))
+
+	// When
+	recorder := serve(handler)
+
+	// Then
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "foo", recorder.Header().Get("X-Test"))
+	assert.Equal(t, "bar", recorder.Body.String())
+}
+
+func TestHandlerWithRateLimiter(t *testing.T) {
+	// Given
+	rl := ratelimiter.Smooth[*http.Response](1, 2*time.Second)
+	handler := NewHandler(okHandler(), rl)
+
+	// When / Then
+	assert.Equal(t, http.StatusOK, serve(handler).Code)
+	recorder := serve(handler)
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+}
+
+// Tests that rejections do not reserve permits, and that Retry-After is only based on the RateLimiter that rejected.
+func TestHandlerWithRateLimiterShouldNotReservePermits(t *testing.T) {
+	// Given
+	rl1 := ratelimiter.Smooth[*http.Response](1, 2*time.Second)
+	rl2 := ratelimiter.Bursty[*http.Response](2, time.Minute).Build()
+	handler := NewHandler(okHandler(), rl1, rl2)
+	assert.Equal(t, http.StatusOK, serve(handler).Code)
+
+	// When / Then
+	for i := 0; i < 3; i++ {
+		recorder := serve(handler)
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+	}
+	assert.True(t, rl2.TryAcquirePermit())
+}
+
+// Tests that a CircuitBreaker can handle error responses from a handler, and that requests are rejected while it's open.
+func TestHandlerWithCircuitBreaker(t *testing.T) {
+	// Given
+	cb := circuitbreaker.Builder[*http.Response]().
+		HandleIf(func(response *http.Response, err error) bool {
+			return response != nil && response.StatusCode >= 500
+		}).
+		WithDelay(time.Minute).
+		Build()
+	handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}), cb)
+
+	// When / Then
+	assert.Equal(t, http.StatusInternalServerError, serve(handler).Code)
+	assert.True(t, cb.IsOpen())
+	recorder := serve(handler)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+}
+
+func TestHandlerWithBulkhead(t *testing.T) {
+	// Given
+	bh := bulkhead.With[*http.Response](1)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}), bh)
+	go serve(handler)
+	<-started
+
+	// When
+	recorder := serve(handler)
+	close(release)
+
+	// Then
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("Retry-After"))
+}
+
+// Tests that a timeout results in a 504, and that headers set by the handler before the timeout are not included.
+func TestHandlerWithTimeout(t *testing.T) {
+	// Given
+	handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test", "foo")
+		<-r.Context().Done()
+	}), timeout.With[*http.Response](50*time.Millisecond))
+
+	// When
+	recorder := serve(handler)
+
+	// Then
+	assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("X-Test"))
+}
+
+// Tests that connections can be hijacked through a handler, such as for websocket upgrades.
+func TestHandlerWithHijack(t *testing.T) {
+	// Given
+	server := httptest.NewServer(NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n")
+		rw.Flush()
+	}), bulkhead.With[*http.Response](1)))
+	defer server.Close()
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	// When
+	conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n"))
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+}
+
+// Tests that an http.ResponseController can reach the underlying http.ResponseWriter through a handler.
+func TestHandlerWithResponseController(t *testing.T) {
+	// Given
+	var deadlineErr error
+	server := httptest.NewServer(NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadlineErr = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(time.Second))
+		w.WriteHeader(http.StatusOK)
+	})))
+	defer server.Close()
+
+	// When
+	resp, err := http.Get(server.URL)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NoError(t, deadlineErr)
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func serve(handler http.Handler) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	return recorder
+}
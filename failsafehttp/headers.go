@@ -0,0 +1,140 @@
+package failsafehttp
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+)
+
+// The max delay that will be parsed from a response header, which guards against absurd values.
+const maxHeaderDelay = 10 * time.Minute
+
+// Header values above this are treated as Unix timestamps rather than a number of seconds.
+const minUnixTimestamp = 1_000_000_000
+
+// RetryAfter returns the delay from a response's Retry-After header, which may contain either a number of seconds or an
+// HTTP date, along with whether a valid header was present. Delays are capped at 10 minutes, and HTTP dates in the past
+// result in a delay of 0.
+func RetryAfter(response *http.Response) (time.Duration, bool) {
+	value := headerValue(response, "Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return secondsToDelay(float64(seconds)), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return capDelay(time.Until(date)), true
+	}
+	return 0, false
+}
+
+// RateLimitReset returns the delay until a server's rate limit resets, from a response's RateLimit-Reset or
+// X-RateLimit-Reset header, along with whether a valid header was present. RateLimit-Reset headers contain a number of
+// seconds, while X-RateLimit-Reset headers may contain either a number of seconds or a Unix timestamp. Delays are capped
+// at 10 minutes.
+func RateLimitReset(response *http.Response) (time.Duration, bool) {
+	if value := headerValue(response, "RateLimit-Reset"); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+			return secondsToDelay(seconds), true
+		}
+	}
+	if value := headerValue(response, "X-RateLimit-Reset"); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+			if seconds > minUnixTimestamp {
+				sec, frac := math.Modf(seconds)
+				return capDelay(time.Until(time.Unix(int64(sec), int64(frac*float64(time.Second))))), true
+			}
+			return secondsToDelay(seconds), true
+		}
+	}
+	return 0, false
+}
+
+// RateLimitRemaining returns the remaining number of requests that a server will permit before its rate limit resets,
+// from a response's RateLimit-Remaining or X-RateLimit-Remaining header, along with whether a valid header was present.
+func RateLimitRemaining(response *http.Response) (uint, bool) {
+	for _, name := range []string{"RateLimit-Remaining", "X-RateLimit-Remaining"} {
+		if value := headerValue(response, name); value != "" {
+			if remaining, err := strconv.ParseUint(value, 10, 0); err == nil {
+				return uint(remaining), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func headerValue(response *http.Response, name string) string {
+	if response == nil {
+		return ""
+	}
+	return strings.TrimSpace(response.Header.Get(name))
+}
+
+func secondsToDelay(seconds float64) time.Duration {
+	if seconds >= maxHeaderDelay.Seconds() {
+		return maxHeaderDelay
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func capDelay(delay time.Duration) time.Duration {
+	return min(max(delay, 0), maxHeaderDelay)
+}
+
+type quotaRoundTripper struct {
+	next        http.RoundTripper
+	rateLimiter ratelimiter.RateLimiter[*http.Response]
+}
+
+// NewQuotaRoundTripper returns a new http.RoundTripper that performs requests via the innerRoundTripper, and that feeds
+// a server's remaining rate limit quota into the rateLimiter. When a response indicates that no requests remain, via a
+// RateLimit-Remaining or X-RateLimit-Remaining header, permits are reserved from the rateLimiter until the server's rate
+// limit resets, as indicated by a RateLimit-Reset, X-RateLimit-Reset, or Retry-After header. This causes executions that
+// use the rateLimiter to wait or be rejected until the server will accept requests again. If innerRoundTripper is nil,
+// http.DefaultTransport will be used.
+//
+// To feed each attempt's response into the rateLimiter, the result can be used as the innerRoundTripper for
+// NewRoundTripper along with the rateLimiter.
+func NewQuotaRoundTripper(innerRoundTripper http.RoundTripper, rateLimiter ratelimiter.RateLimiter[*http.Response]) http.RoundTripper {
+	if innerRoundTripper == nil {
+		innerRoundTripper = http.DefaultTransport
+	}
+	return &quotaRoundTripper{
+		next:        innerRoundTripper,
+		rateLimiter: rateLimiter,
+	}
+}
+
+func (r *quotaRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := r.next.RoundTrip(request)
+	if remaining, ok := RateLimitRemaining(response); ok && remaining == 0 {
+		reset, ok := RateLimitReset(response)
+		if !ok {
+			reset, ok = RetryAfter(response)
+		}
+		if ok {
+			reservePermitsUntil(r.rateLimiter, reset)
+		}
+	}
+	return response, err
+}
+
+// reservePermitsUntil reserves permits from the rateLimiter until no more are available before the delay. Permits are
+// reserved in increasing batches to avoid reserving them one at a time.
+func reservePermitsUntil(rateLimiter ratelimiter.RateLimiter[*http.Response], delay time.Duration) {
+	for permits := uint(1); permits > 0; {
+		if rateLimiter.TryReservePermits(permits, delay) >= 0 {
+			permits *= 2
+		} else {
+			permits /= 2
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package failsafehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+)
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expectedDelay time.Duration
+		expectedOk    bool
+	}{
+		{"seconds", "5", 5 * time.Second, true},
+		{"absurd seconds", "99999999999999", 10 * time.Minute, true},
+		{"negative seconds", "-1", 0, false},
+		{"invalid", "foo", 0, false},
+		{"missing", "", 0, false},
+		{"past date", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, true},
+		{"far future date", time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat), 10 * time.Minute, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, ok := RetryAfter(responseWithHeader("Retry-After", tc.value))
+			assert.Equal(t, tc.expectedDelay, delay)
+			assert.Equal(t, tc.expectedOk, ok)
+		})
+	}
+}
+
+func TestRetryAfterWithDate(t *testing.T) {
+	date := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+
+	delay, ok := RetryAfter(responseWithHeader("Retry-After", date))
+
+	assert.True(t, ok)
+	assert.InDelta(t, 30*time.Second, delay, float64(2*time.Second))
+}
+
+func TestRateLimitReset(t *testing.T) {
+	delay, ok := RateLimitReset(responseWithHeader("RateLimit-Reset", "3"))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	delay, ok = RateLimitReset(responseWithHeader("X-RateLimit-Reset", "2"))
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, delay)
+
+	timestamp := strconv.FormatInt(time.Now().Add(20*time.Second).Unix(), 10)
+	delay, ok = RateLimitReset(responseWithHeader("X-RateLimit-Reset", timestamp))
+	assert.True(t, ok)
+	assert.InDelta(t, 20*time.Second, delay, float64(2*time.Second))
+
+	_, ok = RateLimitReset(responseWithHeader("RateLimit-Reset", "foo"))
+	assert.False(t, ok)
+}
+
+func TestRateLimitRemaining(t *testing.T) {
+	remaining, ok := RateLimitRemaining(responseWithHeader("RateLimit-Remaining", "5"))
+	assert.True(t, ok)
+	assert.Equal(t, uint(5), remaining)
+
+	remaining, ok = RateLimitRemaining(responseWithHeader("X-RateLimit-Remaining", "0"))
+	assert.True(t, ok)
+	assert.Equal(t, uint(0), remaining)
+
+	_, ok = RateLimitRemaining(responseWithHeader("RateLimit-Remaining", "-1"))
+	assert.False(t, ok)
+}
+
+// Tests that DelayFunc can be used to delay a CircuitBreaker according to a Retry-After header.
+func TestCircuitBreakerWithDelayFunc(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	cb := circuitbreaker.Builder[*http.Response]().
+		HandleIf(func(response *http.Response, err error) bool {
+			return response != nil && response.StatusCode == http.StatusServiceUnavailable
+		}).
+		WithDelayFunc(DelayFunc()).
+		Build()
+	client := http.Client{Transport: NewRoundTripper(nil, cb)}
+
+	// When
+	resp, err := client.Get(server.URL)
+
+	// Then
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.True(t, cb.IsOpen())
+	assert.InDelta(t, 30*time.Second, cb.RemainingDelay(), float64(time.Second))
+}
+
+// Tests that a server's exhausted rate limit quota causes permits to be reserved from a RateLimiter until the reset.
+func TestQuotaRoundTripper(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Remaining", "0")
+		w.Header().Set("RateLimit-Reset", "1")
+	}))
+	defer server.Close()
+	rl := ratelimiter.Smooth[*http.Response](100, time.Second)
+	client := http.Client{Transport: NewRoundTripper(NewQuotaRoundTripper(nil, rl), rl)}
+
+	// When
+	resp, err := client.Get(server.URL)
+
+	// Then
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.False(t, rl.TryAcquirePermit())
+	assert.GreaterOrEqual(t, rl.ReservePermit(), 900*time.Millisecond)
+}
+
+func responseWithHeader(name string, value string) *http.Response {
+	response := &http.Response{Header: make(http.Header)}
+	if value != "" {
+		response.Header.Set(name, value)
+	}
+	return response
+}
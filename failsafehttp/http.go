@@ -13,7 +13,9 @@ type roundTripper struct {
 
 // NewRoundTripper returns a new http.RoundTripper that will perform failsafe round trips via the policies and
 // innerRoundTripper. If innerRoundTripper is nil, http.DefaultTransport will be used. The policies are composed around
-// requests and will handle responses in reverse order.
+// requests and will handle responses in reverse order. Request bodies are replayed for each attempt. See BufferBody.
+// Responses from attempts that are not returned, such as responses that were retried, replaced by a fallback, or that
+// lost to a hedge, are drained and closed. Attempts can be traced via WithTracing.
 func NewRoundTripper(innerRoundTripper http.RoundTripper, policies ...failsafe.Policy[*http.Response]) http.RoundTripper {
 	if innerRoundTripper == nil {
 		innerRoundTripper = http.DefaultTransport
@@ -25,9 +27,29 @@ func NewRoundTripper(innerRoundTripper http.RoundTripper, policies ...failsafe.P
 }
 
 func (f *roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
-	return f.executor.GetWithExecution(func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
-		return f.next.RoundTrip(request.WithContext(exec.Context()))
+	replayable, err := newReplayableRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	tracker := &responseTracker{}
+	response, err := executorFor(f.executor, request, tracker).GetWithExecution(func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
+		attemptRequest, err := replayable.forAttempt(exec.Context())
+		if err != nil {
+			return nil, err
+		}
+		attemptRequest, done := traceAttempt(exec, attemptRequest)
+		response, err := f.next.RoundTrip(attemptRequest)
+		done()
+		return tracker.track(response), withRequest(attemptRequest, err)
 	})
+
+	// An http.Client ignores responses that are returned with an error, so discard them
+	if err != nil {
+		tracker.finish(nil)
+		return nil, withoutRequest(err)
+	}
+	tracker.finish(response)
+	return response, nil
 }
 
 type Request struct {
@@ -52,8 +74,24 @@ func NewRequestWithExecutor(request *http.Request, client *http.Client, executor
 	}
 }
 
+// Do performs the request, providing a fresh body for each attempt. See BufferBody. Responses from attempts that are not
+// returned, such as responses that were retried, replaced by a fallback, or that lost to a hedge, are drained and closed.
 func (r *Request) Do() (*http.Response, error) {
-	return r.executor.GetWithExecution(func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
-		return r.client.Do(r.request.WithContext(exec.Context()))
+	replayable, err := newReplayableRequest(r.request)
+	if err != nil {
+		return nil, err
+	}
+	tracker := &responseTracker{}
+	response, err := executorFor(r.executor, r.request, tracker).GetWithExecution(func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
+		attemptRequest, err := replayable.forAttempt(exec.Context())
+		if err != nil {
+			return nil, err
+		}
+		attemptRequest, done := traceAttempt(exec, attemptRequest)
+		response, err := r.client.Do(attemptRequest)
+		done()
+		return tracker.track(response), withRequest(attemptRequest, err)
 	})
+	tracker.finish(response)
+	return response, withoutRequest(err)
 }
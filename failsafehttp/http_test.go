@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/circuitbreaker"
 	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
 	"github.com/failsafe-go/failsafe-go/retrypolicy"
 	"github.com/failsafe-go/failsafe-go/timeout"
@@ -169,6 +172,232 @@ func TestCancelWithTimeout(t *testing.T) {
 		1, 1, timeout.ErrExceeded)
 }
 
+// Tests that a failsafe roundtripper's executions are canceled when the request's context is canceled, without tracing
+// being enabled.
+func TestCancelWithRequestContext(t *testing.T) {
+	// Given
+	server := testutil.MockDelayedResponse(200, "bad", time.Second)
+	defer server.Close()
+	var attempts atomic.Int32
+	rp := retrypolicy.Builder[*http.Response]().
+		OnRetry(func(e failsafe.ExecutionEvent[*http.Response]) {
+			attempts.Add(1)
+		}).
+		Build()
+	client := http.Client{Transport: NewRoundTripper(nil, rp)}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	// When
+	start := time.Now()
+	_, err := client.Do(req)
+
+	// Then
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, int32(0), attempts.Load())
+}
+
+// Asserts that the response from a hedge that loses is drained and closed, without configuring an OnDiscarded listener.
+func TestHedgePolicyClosesDiscardedResponses(t *testing.T) {
+	// Given
+	requests := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+			fmt.Fprintf(w, "slow")
+			return
+		}
+		fmt.Fprintf(w, "fast")
+	}))
+	defer server.Close()
+	transport := &closeTrackingTransport{}
+	hp := hedgepolicy.BuilderWithDelay[*http.Response](20 * time.Millisecond).
+		CancelIf(func(response *http.Response, err error) bool {
+			return err == nil
+		}).
+		Build()
+	client := http.Client{Transport: NewRoundTripper(transport, hp)}
+
+	// When
+	resp, err := client.Get(server.URL)
+
+	// Then
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "fast", string(body))
+	assert.Eventually(t, func() bool {
+		return transport.closes.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+	resp.Body.Close()
+	assert.Equal(t, int32(2), transport.closes.Load())
+}
+
+// Asserts that responses that are retried are drained and closed.
+func TestRetryPolicyClosesRetriedResponses(t *testing.T) {
+	// Given
+	server := testutil.MockFlakyServer(2, 503, 0, "foo")
+	defer server.Close()
+	transport := &closeTrackingTransport{}
+	client := http.Client{Transport: NewRoundTripper(transport, RetryPolicyBuilder().Build())}
+
+	// When
+	resp, err := client.Get(server.URL)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), transport.closes.Load())
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "foo", string(body))
+	resp.Body.Close()
+	assert.Equal(t, int32(3), transport.closes.Load())
+}
+
+// Asserts that responses are drained and closed when their retry is scheduled, rather than when the execution is done.
+func TestRetryPolicyClosesRetriedResponsesBeforeRetrying(t *testing.T) {
+	// Given
+	server := testutil.MockFlakyServer(2, 503, 0, "foo")
+	defer server.Close()
+	transport := &closeTrackingTransport{}
+	var closesBeforeRetries []int32
+	rp := RetryPolicyBuilder().
+		OnRetry(func(failsafe.ExecutionEvent[*http.Response]) {
+			closesBeforeRetries = append(closesBeforeRetries, transport.closes.Load())
+		}).
+		Build()
+	client := http.Client{Transport: NewRoundTripper(transport, rp)}
+
+	// When
+	resp, err := client.Get(server.URL)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{1, 2}, closesBeforeRetries)
+	resp.Body.Close()
+}
+
+// Asserts that a response whose body is replaced, such as by a fallback, is returned with the replaced body.
+func TestFallbackWithReplacedBody(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(400, "bad")
+	defer server.Close()
+	fb := fallback.BuilderWithFunc[*http.Response](func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
+		resp := exec.LastResult()
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewBufferString("replaced"))
+		return resp, nil
+	}).HandleIf(func(response *http.Response, err error) bool {
+		return response.StatusCode == 400
+	}).Build()
+	client := http.Client{Transport: NewRoundTripper(nil, fb)}
+
+	// When
+	resp, err := client.Get(server.URL)
+
+	// Then
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "replaced", string(body))
+}
+
+// Asserts that responses that are returned along with an error from a RoundTripper are drained and closed, since they're
+// ignored by an http.Client.
+func TestRetryPolicyClosesExceededResponses(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(503, "foo")
+	defer server.Close()
+	transport := &closeTrackingTransport{}
+	client := http.Client{Transport: NewRoundTripper(transport, RetryPolicyBuilder().Build())}
+
+	// When
+	_, err := client.Get(server.URL)
+
+	// Then
+	assert.ErrorIs(t, err, retrypolicy.ErrExceeded)
+	assert.Equal(t, int32(3), transport.closes.Load())
+}
+
+// Asserts that a response that is replaced by a fallback is drained and closed.
+func TestFallbackClosesOriginalResponse(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(400, "bad")
+	defer server.Close()
+	fb := fallback.BuilderWithFunc[*http.Response](func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString("fallback")),
+		}, nil
+	}).HandleIf(func(response *http.Response, err error) bool {
+		return response.StatusCode == 400
+	}).Build()
+	transport := &closeTrackingTransport{}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	// When
+	resp, err := NewRequest(req, &http.Client{Transport: transport}, fb).Do()
+
+	// Then
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "fallback", string(body))
+	assert.Equal(t, int32(1), transport.closes.Load())
+}
+
+// Asserts that the response from a hedge that loses is drained and closed, even without an OnDiscarded listener.
+func TestHedgeClosesLosingResponses(t *testing.T) {
+	// Given
+	requests := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+	transport := &closeTrackingTransport{}
+	hp := hedgepolicy.BuilderWithDelay[*http.Response](20 * time.Millisecond).
+		CancelIf(func(response *http.Response, err error) bool {
+			return err == nil
+		}).
+		Build()
+	client := http.Client{Transport: NewRoundTripper(transport, hp)}
+
+	// When
+	resp, err := client.Get(server.URL)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return transport.closes.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+	resp.Body.Close()
+	assert.Equal(t, int32(2), transport.closes.Load())
+}
+
+// closeTrackingTransport tracks the number of response bodies that have been closed.
+type closeTrackingTransport struct {
+	closes atomic.Int32
+}
+
+func (t *closeTrackingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	// Don't propagate cancellation so that losing hedges return a response
+	resp, err := http.DefaultTransport.RoundTrip(request.WithContext(context.Background()))
+	if resp != nil {
+		resp.Body = &closeTrackingBody{ReadCloser: resp.Body, closes: &t.closes}
+	}
+	return resp, err
+}
+
+type closeTrackingBody struct {
+	io.ReadCloser
+	closes *atomic.Int32
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closes.Add(1)
+	return b.ReadCloser.Close()
+}
+
 func testRequestSuccess(t *testing.T, url string, executor failsafe.Executor[*http.Response], expectedAttempts int, expectedExecutions int, expectedStatus int, expectedResult any, then ...func()) {
 	testRequest(t, url, executor, expectedAttempts, expectedExecutions, expectedStatus, expectedResult, nil, true, then...)
 }
@@ -0,0 +1,199 @@
+package failsafehttp
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/adaptivethrottle"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+)
+
+// IdempotencyKeyHeader is the header used to indicate that a request with a non-idempotent method, such as a POST, can
+// be safely retried since the server will only process it once.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// retryableMethods are the methods that can be safely retried since they are idempotent.
+var retryableMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// requestKey is a key to use with a Context that stores the request that an execution is performing.
+const requestKey key = 1
+
+// isRetryable returns whether the request can be safely retried, either because its method is idempotent or because it
+// has an Idempotency-Key header. Returns false if the request is nil, since its method is unknown.
+func isRetryable(request *http.Request) bool {
+	if request == nil {
+		return false
+	}
+	method := request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return retryableMethods[method] || request.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// isUnsafeToRetry returns whether the failed attempt of the exec should not be retried, since its request is not safe to
+// retry and the failure does not prove that the request was not processed.
+func isUnsafeToRetry(exec failsafe.Execution[*http.Response]) bool {
+	if resp := exec.LastResult(); resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return false
+	}
+	if err := exec.LastError(); err != nil && (isNotSent(err) || isRejected(err)) {
+		return false
+	}
+	if request := requestFor(exec); request != nil {
+		return !isRetryable(request)
+	}
+	// Fall back to the method of a url.Error from an http.Client, else allow the retry, since the request is not known
+	var urlErr *url.Error
+	if errors.As(exec.LastError(), &urlErr) {
+		return !retryableMethods[strings.ToUpper(urlErr.Op)]
+	}
+	return false
+}
+
+// requestFor returns the request that the exec is performing, else the request of the exec's last response or error,
+// else nil if the request is not known.
+func requestFor(exec failsafe.Execution[*http.Response]) *http.Request {
+	if request, ok := exec.Context().Value(requestKey).(*http.Request); ok {
+		return request
+	}
+	if resp := exec.LastResult(); resp != nil && resp.Request != nil {
+		return resp.Request
+	}
+	var reqErr *requestError
+	if errors.As(exec.LastError(), &reqErr) {
+		return reqErr.request
+	}
+	return nil
+}
+
+// isNotSent returns whether the err proves that a request was not sent, such as a dial or DNS failure.
+func isNotSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// isRejected returns whether the err indicates that a policy rejected an attempt before its request was sent.
+func isRejected(err error) bool {
+	return errors.Is(err, circuitbreaker.ErrOpen) ||
+		errors.Is(err, bulkhead.ErrFull) ||
+		errors.Is(err, ratelimiter.ErrExceeded) ||
+		errors.Is(err, adaptivethrottle.ErrThrottled)
+}
+
+// requestObserver is a failsafe.Observer that stores a request in the Context of an execution, so that policies can
+// determine whether the request is safe to retry.
+type requestObserver struct {
+	request *http.Request
+}
+
+func (o requestObserver) ExecutionStarted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestKey, o.request)
+}
+
+func (o requestObserver) AttemptStarted(ctx context.Context, _ failsafe.AttemptStats) context.Context {
+	return ctx
+}
+
+func (o requestObserver) AttemptDone(context.Context, failsafe.AttemptStats, error) {
+}
+
+func (o requestObserver) PolicyEvent(context.Context, failsafe.PolicyEvent) {
+}
+
+func (o requestObserver) ExecutionDone(context.Context, failsafe.ExecutionStats, error) {
+}
+
+// requestError is an error for an attempt of a request, which allows retries to be classified by the request. These are
+// unwrapped before being returned from a failsafe round trip.
+type requestError struct {
+	request *http.Request
+	err     error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// withRequest returns the err wrapped with the request, if not nil.
+func withRequest(request *http.Request, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &requestError{request: request, err: err}
+}
+
+// withoutRequest returns the err unwrapped from any request.
+func withoutRequest(err error) error {
+	if re, ok := err.(*requestError); ok {
+		return re.err
+	}
+	return err
+}
+
+// SetIdempotencyKey sets a random Idempotency-Key header on the request if it has a non-idempotent method, such as a POST,
+// and does not already have the header. This allows RetryPolicyBuilder to retry the request. The header is only useful
+// for servers that support idempotency keys.
+func SetIdempotencyKey(request *http.Request) {
+	if !isRetryable(request) {
+		if request.Header == nil {
+			request.Header = make(http.Header)
+		}
+		request.Header.Set(IdempotencyKeyHeader, newIdempotencyKey())
+	}
+}
+
+type idempotencyKeyRoundTripper struct {
+	next http.RoundTripper
+}
+
+// NewIdempotencyKeyRoundTripper returns a new http.RoundTripper that sets a random Idempotency-Key header on requests
+// that have a non-idempotent method and that do not already have the header, before performing them via the
+// innerRoundTripper. In order for the key to be the same for each attempt of a request, the innerRoundTripper should be
+// one returned by NewRoundTripper. If innerRoundTripper is nil, http.DefaultTransport will be used.
+func NewIdempotencyKeyRoundTripper(innerRoundTripper http.RoundTripper) http.RoundTripper {
+	if innerRoundTripper == nil {
+		innerRoundTripper = http.DefaultTransport
+	}
+	return &idempotencyKeyRoundTripper{next: innerRoundTripper}
+}
+
+func (r *idempotencyKeyRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !isRetryable(request) {
+		request = request.Clone(request.Context())
+		SetIdempotencyKey(request)
+	}
+	return r.next.RoundTrip(request)
+}
+
+// newIdempotencyKey returns a random version 4 UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
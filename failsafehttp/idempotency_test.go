@@ -0,0 +1,241 @@
+package failsafehttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+func TestRetryPolicyShouldNotRetryPostResponses(t *testing.T) {
+	// Given
+	server, requests := countingServer(http.StatusServiceUnavailable)
+	defer server.Close()
+	client := http.Client{Transport: NewRoundTripper(nil, RetryPolicyBuilder().Build())}
+
+	// When
+	resp, err := client.Post(server.URL, "text/plain", nil)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRetryPolicyShouldRetryPostWithIdempotencyKey(t *testing.T) {
+	// Given
+	server, requests := countingServer(http.StatusServiceUnavailable)
+	defer server.Close()
+	req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+	req.Header.Set(IdempotencyKeyHeader, "foo")
+
+	// When
+	_, err := NewRequest(req, http.DefaultClient, RetryPolicyBuilder().Build()).Do()
+
+	// Then
+	assert.ErrorIs(t, err, retrypolicy.ErrExceeded)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestRetryPolicyShouldRetryPostThatWasNotSent(t *testing.T) {
+	// Given
+	var attempts atomic.Int32
+	rp := RetryPolicyBuilder().
+		ReturnLastFailure().
+		OnRetry(func(e failsafe.ExecutionEvent[*http.Response]) {
+			attempts.Add(1)
+		}).
+		Build()
+	client := http.Client{Transport: NewRoundTripper(nil, rp)}
+
+	// When
+	_, err := client.Post("http://localhost:55555", "text/plain", nil)
+
+	// Then
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+// Tests that requests performed by an http.Client outside of NewRoundTripper or Request.Do are retried based on the method
+// of their url.Error.
+func TestRetryPolicyWithUnknownRequest(t *testing.T) {
+	test := func(method string, expectedAttempts int32) {
+		// Given
+		var attempts atomic.Int32
+		fn := func() (*http.Response, error) {
+			attempts.Add(1)
+			return nil, &url.Error{Op: method, URL: "http://foo", Err: io.ErrUnexpectedEOF}
+		}
+
+		// When
+		_, err := failsafe.Get(fn, RetryPolicyBuilder().Build())
+
+		// Then
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, expectedAttempts, attempts.Load())
+	}
+
+	test("Get", 3)
+	test("Post", 1)
+}
+
+// Tests that errors that occur after a request was sent are only retried for idempotent requests.
+func TestRetryPolicyWithErrorAfterSend(t *testing.T) {
+	tests := []struct {
+		method           string
+		expectedRequests int32
+	}{
+		{http.MethodPost, 1},
+		{http.MethodPut, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.method, func(t *testing.T) {
+			// Given
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+			}))
+			defer server.Close()
+			req, _ := http.NewRequest(tc.method, server.URL, nil)
+
+			// When
+			_, err := NewRequest(req, http.DefaultClient, RetryPolicyBuilder().Build()).Do()
+
+			// Then
+			assert.Error(t, err)
+			assert.Equal(t, tc.expectedRequests, requests.Load())
+		})
+	}
+}
+
+// Tests that requests that exceed an inner Timeout are only retried for idempotent requests, since the request may have
+// been processed.
+func TestRetryPolicyWithTimeout(t *testing.T) {
+	tests := []struct {
+		method           string
+		expectedRequests int32
+	}{
+		{http.MethodPost, 1},
+		{http.MethodGet, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.method, func(t *testing.T) {
+			// Given
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				time.Sleep(100 * time.Millisecond)
+			}))
+			defer server.Close()
+			to := timeout.With[*http.Response](20 * time.Millisecond)
+			client := http.Client{Transport: NewRoundTripper(nil, RetryPolicyBuilder().ReturnLastFailure().Build(), to)}
+			req, _ := http.NewRequest(tc.method, server.URL, nil)
+
+			// When
+			_, err := client.Do(req)
+
+			// Then
+			assert.ErrorIs(t, err, timeout.ErrExceeded)
+			assert.Equal(t, tc.expectedRequests, requests.Load())
+		})
+	}
+}
+
+// Tests that a POST that is rejected by an inner CircuitBreaker before being sent is retried.
+func TestRetryPolicyShouldRetryPostRejectedByCircuitBreaker(t *testing.T) {
+	// Given
+	server, requests := countingServer(http.StatusOK)
+	defer server.Close()
+	cb := circuitbreaker.WithDefaults[*http.Response]()
+	cb.Open()
+	rp := RetryPolicyBuilder().
+		OnRetry(func(e failsafe.ExecutionEvent[*http.Response]) {
+			cb.Close()
+		}).
+		Build()
+	client := http.Client{Transport: NewRoundTripper(nil, rp, cb)}
+
+	// When
+	resp, err := client.Post(server.URL, "text/plain", nil)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// Tests that an Idempotency-Key is generated once and used for each attempt of a request.
+func TestIdempotencyKeyRoundTripper(t *testing.T) {
+	// Given
+	var mtx sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	client := http.Client{Transport: NewIdempotencyKeyRoundTripper(NewRoundTripper(nil, RetryPolicyBuilder().Build()))}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+
+	// When
+	resp, err := client.Do(req)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, req.Header.Get(IdempotencyKeyHeader))
+	assert.Len(t, keys, 3)
+	assert.Len(t, keys[0], 36)
+	assert.Equal(t, keys[0], keys[1])
+	assert.Equal(t, keys[0], keys[2])
+}
+
+func TestSetIdempotencyKey(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "", nil)
+	SetIdempotencyKey(get)
+	assert.Empty(t, get.Header.Get(IdempotencyKeyHeader))
+
+	post, _ := http.NewRequest(http.MethodPost, "", nil)
+	SetIdempotencyKey(post)
+	assert.Len(t, post.Header.Get(IdempotencyKeyHeader), 36)
+
+	post.Header.Set(IdempotencyKeyHeader, "foo")
+	SetIdempotencyKey(post)
+	assert.Equal(t, "foo", post.Header.Get(IdempotencyKeyHeader))
+}
+
+func TestSetIdempotencyKeyWithoutHeaders(t *testing.T) {
+	post := &http.Request{Method: http.MethodPost}
+	SetIdempotencyKey(post)
+	assert.Len(t, post.Header.Get(IdempotencyKeyHeader), 36)
+}
+
+// countingServer returns a server that always responds with the statusCode, along with the number of requests it received.
+func countingServer(statusCode int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(statusCode)
+	}))
+	return server, &requests
+}
@@ -2,16 +2,20 @@ package failsafehttp
 
 import (
 	"crypto/x509"
+	"errors"
+	"io"
 	"net/http"
 	"net/url"
 	"regexp"
-	"strconv"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/retrypolicy"
 )
 
+// The max number of bytes to read from a discarded response body, so that its connection can be reused.
+const maxDrainBytes = 64 << 10
+
 var (
 	unsupportedScheme     = regexp.MustCompile(`unsupported protocol scheme`)
 	certNotTrusted        = regexp.MustCompile(`certificate is not trusted`)
@@ -21,23 +25,37 @@ var (
 // RetryPolicyBuilder returns a retrypolicy.RetryPolicyBuilder that will retry non-terminal HTTP errors and responses up
 // to 2 times, by default. If a Retry-After header is present in the response, it will be used as a delay between
 // retries. Additional handling and delay configuration can be added to the resulting builder.
+//
+// Requests are only retried when it's safe to do so. Requests with idempotent methods, GET, HEAD, PUT, DELETE, and
+// OPTIONS, are retried on errors and 5xx responses. Requests with other methods, such as POST, are only retried when
+// they have an Idempotency-Key header, when an error proves that the request was not sent, such as a dial failure or a
+// rejection by a CircuitBreaker, Bulkhead, RateLimiter, or AdaptiveThrottler, or when a 429 response indicates that the
+// request was rejected. Retries are aborted for other failures of these requests, including Timeouts, since the request
+// may have been processed. Whether a request is safe to retry is determined by the request performed via NewRoundTripper
+// or Request.Do, else by the request of a failed response, else by the method of a url.Error. Requests that are not
+// known are retried. See NewIdempotencyKeyRoundTripper and SetIdempotencyKey.
 func RetryPolicyBuilder() retrypolicy.RetryPolicyBuilder[*http.Response] {
 	retryHandleFunc := func(resp *http.Response, err error) bool {
 		// Handle errors
 		if err != nil {
-			if v, ok := err.(*url.Error); ok {
+			// Do not retry requests that cannot be replayed
+			if errors.Is(err, ErrBodyNotReplayable) {
+				return false
+			}
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
 				// Do not retry when certain error messages are observed
-				if unsupportedScheme.MatchString(v.Error()) ||
-					certNotTrusted.MatchString(v.Error()) ||
-					stoppedAfterRedirects.MatchString(v.Error()) {
+				if unsupportedScheme.MatchString(urlErr.Error()) ||
+					certNotTrusted.MatchString(urlErr.Error()) ||
+					stoppedAfterRedirects.MatchString(urlErr.Error()) {
 					return false
 				}
 				// Do not retry on unknown authority errors
-				if _, ok := v.Err.(x509.UnknownAuthorityError); ok {
+				if _, ok := urlErr.Err.(x509.UnknownAuthorityError); ok {
 					return false
 				}
 			}
-			// Retry on all other url errors
+			// Retry on all other errors
 			return true
 		}
 
@@ -58,22 +76,34 @@ func RetryPolicyBuilder() retrypolicy.RetryPolicyBuilder[*http.Response] {
 
 	return retrypolicy.Builder[*http.Response]().
 		HandleIf(retryHandleFunc).
+		AbortIfExecution(isUnsafeToRetry).
 		WithDelayFunc(DelayFunc())
 }
 
-// DelayFunc returns a failsafe.DelayFunc that delays according to an http.Response Retry-After header. This can be used
-// as a delay in a RetryPolicy or a CircuitBreaker.
+// DelayFunc returns a failsafe.DelayFunc that delays according to an http.Response's Retry-After header, or else its
+// RateLimit-Reset or X-RateLimit-Reset header, for 429 and 503 responses. Delays are capped at 10 minutes. This can be
+// used as a delay in a RetryPolicy or a CircuitBreaker. See RetryAfter and RateLimitReset.
 func DelayFunc() failsafe.DelayFunc[*http.Response] {
 	return func(exec failsafe.ExecutionAttempt[*http.Response]) time.Duration {
 		resp := exec.LastResult()
 		if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
-			if header, ok := resp.Header["Retry-After"]; ok {
-				if seconds, err := strconv.Atoi(header[0]); err == nil {
-					return time.Second * time.Duration(seconds)
-				}
+			if delay, ok := RetryAfter(resp); ok {
+				return delay
+			}
+			if delay, ok := RateLimitReset(resp); ok {
+				return delay
 			}
 		}
 
 		return -1
 	}
 }
+
+// DiscardResponse drains and closes the body of the response, if any, so that its connection can be reused. Up to 64 KB of
+// the body is drained. This can be used as a hedgepolicy.HedgePolicyBuilder OnDiscarded listener.
+func DiscardResponse(response *http.Response, _ error) {
+	if response != nil && response.Body != nil {
+		io.CopyN(io.Discard, response.Body, maxDrainBytes)
+		response.Body.Close()
+	}
+}
@@ -0,0 +1,119 @@
+package failsafehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// responseTracker tracks the responses from each attempt of a request, so that any responses that are not returned,
+// such as responses that were retried, replaced by a fallback, or that lost to a hedge, can be discarded. It's also a
+// failsafe.Observer that discards responses as soon as they're retried, so that their connections are not held through
+// retry delays.
+type responseTracker struct {
+	mtx       sync.Mutex
+	responses []*http.Response
+	done      bool
+}
+
+// track tracks the response from an attempt. If the request is already done, as with a late hedge, the response is
+// discarded.
+func (t *responseTracker) track(response *http.Response) *http.Response {
+	if response == nil || response.Body == nil {
+		return response
+	}
+	response.Body = &trackedBody{ReadCloser: response.Body}
+
+	t.mtx.Lock()
+	if t.done {
+		t.mtx.Unlock()
+		DiscardResponse(response, nil)
+		return response
+	}
+	t.responses = append(t.responses, response)
+	t.mtx.Unlock()
+	return response
+}
+
+// finish discards any tracked responses other than the result, which is returned with its original body.
+func (t *responseTracker) finish(result *http.Response) {
+	t.mtx.Lock()
+	t.done = true
+	responses := t.responses
+	t.responses = nil
+	t.mtx.Unlock()
+
+	for _, response := range responses {
+		if response == result {
+			// The result's body may have been replaced, such as by a listener
+			if tb, ok := result.Body.(*trackedBody); ok {
+				result.Body = tb.ReadCloser
+			}
+		} else {
+			DiscardResponse(response, nil)
+		}
+	}
+}
+
+// discard discards the response, if it's tracked.
+func (t *responseTracker) discard(response *http.Response) {
+	t.mtx.Lock()
+	index := slices.Index(t.responses, response)
+	if index == -1 {
+		t.mtx.Unlock()
+		return
+	}
+	t.responses = slices.Delete(t.responses, index, index+1)
+	t.mtx.Unlock()
+	DiscardResponse(response, nil)
+}
+
+func (t *responseTracker) ExecutionStarted(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (t *responseTracker) AttemptStarted(ctx context.Context, _ failsafe.AttemptStats) context.Context {
+	return ctx
+}
+
+func (t *responseTracker) AttemptDone(context.Context, failsafe.AttemptStats, error) {
+}
+
+// PolicyEvent discards the response that a RetryScheduled event is for.
+func (t *responseTracker) PolicyEvent(_ context.Context, event failsafe.PolicyEvent) {
+	if event.Type != failsafe.RetryScheduled {
+		return
+	}
+	if exec, ok := event.AttemptStats.(failsafe.Execution[*http.Response]); ok && exec.LastResult() != nil {
+		t.discard(exec.LastResult())
+	}
+}
+
+func (t *responseTracker) ExecutionDone(context.Context, failsafe.ExecutionStats, error) {
+}
+
+// trackedBody is a response body that can only be closed once, so that it's safe to discard more than once, such as
+// by a HedgePolicy OnDiscarded listener and a responseTracker.
+type trackedBody struct {
+	io.ReadCloser
+	closed atomic.Bool
+}
+
+func (b *trackedBody) Read(p []byte) (int, error) {
+	if b.closed.Load() {
+		return 0, io.EOF
+	}
+	return b.ReadCloser.Read(p)
+}
+
+func (b *trackedBody) Close() error {
+	if b.closed.CompareAndSwap(false, true) {
+		return b.ReadCloser.Close()
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package failsafehttp
+
+import (
+	"container/list"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// RouterBuilder builds http.RoundTripper instances that select the policies to perform each request with based on the
+// request's host, path, method, or a custom matcher. Routes are evaluated in the order they're configured, and the first
+// matching route is used. Requests that don't match any route are performed with the default policies.
+//
+// Each route performs requests with its own executor, so stateful policies such as a CircuitBreaker, Bulkhead, or
+// RateLimiter can be created separately for each route, or the same policy instances can be provided to multiple routes
+// to share them.
+//
+// This type is not concurrency safe.
+type RouterBuilder interface {
+	// WithHost routes requests for the host to the policies. If the host does not contain a port, requests for the host
+	// with any port are matched. IPv6 hosts may be provided with or without brackets, such as [::1] or ::1, and must
+	// include brackets when they include a port, such as [::1]:8080. Hosts are matched case-insensitively.
+	WithHost(host string, policies ...failsafe.Policy[*http.Response]) RouterBuilder
+
+	// WithPathPrefix routes requests whose URL path starts with the prefix to the policies.
+	WithPathPrefix(prefix string, policies ...failsafe.Policy[*http.Response]) RouterBuilder
+
+	// WithMethod routes requests with the method to the policies.
+	WithMethod(method string, policies ...failsafe.Policy[*http.Response]) RouterBuilder
+
+	// WithMatcher routes requests that satisfy the matcher to the policies.
+	WithMatcher(matcher func(request *http.Request) bool, policies ...failsafe.Policy[*http.Response]) RouterBuilder
+
+	// WithDefaults configures the policies to perform requests with when they don't match any route. By default, requests
+	// that don't match any route are performed without any policies.
+	WithDefaults(policies ...failsafe.Policy[*http.Response]) RouterBuilder
+
+	// WithPerHostDefaults configures a function that creates policies for each distinct host of requests that don't match
+	// any route. The policiesFunc is called once per host, and the resulting policies are reused for subsequent requests to
+	// the host. This is useful for giving each host its own stateful policies, such as a CircuitBreaker. Policies are
+	// retained for up to 1024 of the most recently requested hosts, and are created again via the policiesFunc if a host
+	// is requested after being evicted. Takes precedence over WithDefaults.
+	WithPerHostDefaults(policiesFunc func(host string) []failsafe.Policy[*http.Response]) RouterBuilder
+
+	// Build returns a new http.RoundTripper using the builder's configuration.
+	Build() http.RoundTripper
+}
+
+type routerConfig struct {
+	next            http.RoundTripper
+	routes          []*route
+	defaults        []failsafe.Policy[*http.Response]
+	perHostDefaults func(host string) []failsafe.Policy[*http.Response]
+}
+
+var _ RouterBuilder = &routerConfig{}
+
+type route struct {
+	matcher  func(request *http.Request) bool
+	policies []failsafe.Policy[*http.Response]
+}
+
+type router struct {
+	next            http.RoundTripper
+	routes          []*routeRoundTripper
+	defaults        http.RoundTripper
+	perHostDefaults func(host string) []failsafe.Policy[*http.Response]
+
+	mtx          sync.Mutex
+	hostDefaults map[string]*list.Element // Guarded by mtx
+	hosts        *list.List               // Guarded by mtx. Hosts with default policies, most recently requested first.
+}
+
+// maxHostDefaults is the max number of hosts to retain per host default policies for.
+const maxHostDefaults = 1024
+
+// hostRoundTripper is an http.RoundTripper for a host's default policies.
+type hostRoundTripper struct {
+	host         string
+	roundTripper http.RoundTripper
+}
+
+type routeRoundTripper struct {
+	matcher      func(request *http.Request) bool
+	roundTripper http.RoundTripper
+}
+
+// NewRouterBuilder returns a new RouterBuilder that will perform failsafe round trips via the innerRoundTripper. If
+// innerRoundTripper is nil, http.DefaultTransport will be used.
+func NewRouterBuilder(innerRoundTripper http.RoundTripper) RouterBuilder {
+	if innerRoundTripper == nil {
+		innerRoundTripper = http.DefaultTransport
+	}
+	return &routerConfig{
+		next: innerRoundTripper,
+	}
+}
+
+func (c *routerConfig) WithHost(host string, policies ...failsafe.Policy[*http.Response]) RouterBuilder {
+	_, _, err := net.SplitHostPort(host)
+	matchPort := err == nil
+	hostname := strings.Trim(host, "[]")
+	return c.WithMatcher(func(request *http.Request) bool {
+		if matchPort {
+			return strings.EqualFold(requestHost(request), host)
+		}
+		return strings.EqualFold(requestHostname(request), hostname)
+	}, policies...)
+}
+
+func (c *routerConfig) WithPathPrefix(prefix string, policies ...failsafe.Policy[*http.Response]) RouterBuilder {
+	return c.WithMatcher(func(request *http.Request) bool {
+		return request.URL != nil && strings.HasPrefix(request.URL.Path, prefix)
+	}, policies...)
+}
+
+func (c *routerConfig) WithMethod(method string, policies ...failsafe.Policy[*http.Response]) RouterBuilder {
+	return c.WithMatcher(func(request *http.Request) bool {
+		return request.Method == method || (request.Method == "" && method == http.MethodGet)
+	}, policies...)
+}
+
+func (c *routerConfig) WithMatcher(matcher func(request *http.Request) bool, policies ...failsafe.Policy[*http.Response]) RouterBuilder {
+	c.routes = append(c.routes, &route{
+		matcher:  matcher,
+		policies: policies,
+	})
+	return c
+}
+
+func (c *routerConfig) WithDefaults(policies ...failsafe.Policy[*http.Response]) RouterBuilder {
+	c.defaults = policies
+	return c
+}
+
+func (c *routerConfig) WithPerHostDefaults(policiesFunc func(host string) []failsafe.Policy[*http.Response]) RouterBuilder {
+	c.perHostDefaults = policiesFunc
+	return c
+}
+
+func (c *routerConfig) Build() http.RoundTripper {
+	r := &router{
+		next:            c.next,
+		defaults:        NewRoundTripper(c.next, c.defaults...),
+		perHostDefaults: c.perHostDefaults,
+		hostDefaults:    make(map[string]*list.Element),
+		hosts:           list.New(),
+	}
+	for _, rt := range c.routes {
+		r.routes = append(r.routes, &routeRoundTripper{
+			matcher:      rt.matcher,
+			roundTripper: NewRoundTripper(c.next, rt.policies...),
+		})
+	}
+	return r
+}
+
+func (r *router) RoundTrip(request *http.Request) (*http.Response, error) {
+	return r.roundTripperFor(request).RoundTrip(request)
+}
+
+// roundTripperFor returns the http.RoundTripper for the first route that matches the request, else the defaults.
+func (r *router) roundTripperFor(request *http.Request) http.RoundTripper {
+	for _, rt := range r.routes {
+		if rt.matcher(request) {
+			return rt.roundTripper
+		}
+	}
+	if r.perHostDefaults == nil {
+		return r.defaults
+	}
+
+	return r.hostRoundTripperFor(strings.ToLower(requestHost(request)))
+}
+
+// hostRoundTripperFor returns the http.RoundTripper for the host's default policies, creating them if needed. The policies
+// for the least recently requested host are evicted if there are more than maxHostDefaults.
+func (r *router) hostRoundTripperFor(host string) http.RoundTripper {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if element, ok := r.hostDefaults[host]; ok {
+		r.hosts.MoveToFront(element)
+		return element.Value.(*hostRoundTripper).roundTripper
+	}
+	roundTripper := NewRoundTripper(r.next, r.perHostDefaults(host)...)
+	r.hostDefaults[host] = r.hosts.PushFront(&hostRoundTripper{host: host, roundTripper: roundTripper})
+	if r.hosts.Len() > maxHostDefaults {
+		oldest := r.hosts.Remove(r.hosts.Back()).(*hostRoundTripper)
+		delete(r.hostDefaults, oldest.host)
+	}
+	return roundTripper
+}
+
+// requestHost returns the host, including any port, that a request is for.
+func requestHost(request *http.Request) string {
+	if request.URL != nil && request.URL.Host != "" {
+		return request.URL.Host
+	}
+	return request.Host
+}
+
+// requestHostname returns the host, without any port, that a request is for.
+func requestHostname(request *http.Request) string {
+	host := requestHost(request)
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		return hostname
+	}
+	return strings.Trim(host, "[]")
+}
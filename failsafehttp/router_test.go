@@ -0,0 +1,183 @@
+package failsafehttp
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+func TestRouterWithHost(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(200, "foo")
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	cb := openCircuitBreaker()
+	client := http.Client{Transport: NewRouterBuilder(nil).
+		WithHost("localhost", cb).
+		Build()}
+
+	// When / Then
+	_, err := client.Get(strings.Replace(server.URL, "127.0.0.1", "localhost", 1))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assertRouterSuccess(t, client, server.URL)
+
+	// Given a host with a port
+	client = http.Client{Transport: NewRouterBuilder(nil).
+		WithHost(serverURL.Host, cb).
+		Build()}
+
+	// When / Then
+	_, err = client.Get(server.URL)
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+}
+
+func TestRouterWithPathPrefixAndMethod(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(200, "foo")
+	defer server.Close()
+	client := http.Client{Transport: NewRouterBuilder(nil).
+		WithPathPrefix("/admin", openCircuitBreaker()).
+		WithMethod(http.MethodPost, openCircuitBreaker()).
+		Build()}
+
+	// When / Then
+	_, err := client.Get(server.URL + "/admin/users")
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	_, err = client.Post(server.URL+"/users", "text/plain", nil)
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assertRouterSuccess(t, client, server.URL+"/users")
+}
+
+// Tests that the first matching route is used, and that requests that don't match any route use the defaults.
+func TestRouterWithMatcherAndDefaults(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(200, "foo")
+	defer server.Close()
+	client := http.Client{Transport: NewRouterBuilder(nil).
+		WithMatcher(func(request *http.Request) bool {
+			return request.URL.Query().Get("route") == "a"
+		}).
+		WithPathPrefix("/", openCircuitBreaker()).
+		WithMethod(http.MethodPost).
+		WithDefaults(openCircuitBreaker()).
+		Build()}
+
+	// When / Then
+	assertRouterSuccess(t, client, server.URL+"/?route=a")
+	_, err := client.Get(server.URL + "/")
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	resp, err := client.Post(server.URL, "text/plain", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	req, _ := http.NewRequest(http.MethodPut, server.URL, nil)
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+}
+
+// Tests that per host defaults are created once for each host.
+func TestRouterWithPerHostDefaults(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(200, "foo")
+	defer server.Close()
+	var hosts []string
+	client := http.Client{Transport: NewRouterBuilder(nil).
+		WithPerHostDefaults(func(host string) []failsafe.Policy[*http.Response] {
+			hosts = append(hosts, host)
+			return []failsafe.Policy[*http.Response]{circuitbreaker.WithDefaults[*http.Response]()}
+		}).
+		Build()}
+	localhostURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	// When
+	assertRouterSuccess(t, client, server.URL)
+	assertRouterSuccess(t, client, server.URL)
+	assertRouterSuccess(t, client, localhostURL)
+
+	// Then
+	assert.Equal(t, []string{
+		strings.TrimPrefix(server.URL, "http://"),
+		strings.TrimPrefix(localhostURL, "http://"),
+	}, hosts)
+}
+
+// Tests that WithHost matches IPv6 hosts with and without brackets and ports.
+func TestRouterWithIPv6Host(t *testing.T) {
+	for _, tc := range []struct {
+		host     string
+		expected map[string]bool
+	}{
+		{"::1", map[string]bool{"[::1]": true, "[::1]:8080": true, "[::2]:8080": false}},
+		{"[::1]", map[string]bool{"[::1]": true, "[::1]:8080": true, "[::2]": false}},
+		{"[::1]:8080", map[string]bool{"[::1]:8080": true, "[::1]:9090": false, "[::1]": false}},
+	} {
+		t.Run(tc.host, func(t *testing.T) {
+			config := NewRouterBuilder(nil).WithHost(tc.host).(*routerConfig)
+			for requestHost, expected := range tc.expected {
+				request := &http.Request{URL: &url.URL{Host: requestHost}}
+				assert.Equal(t, expected, config.routes[0].matcher(request), requestHost)
+			}
+		})
+	}
+}
+
+// Tests that per host default policies are evicted for the least recently requested hosts.
+func TestRouterShouldEvictHostDefaults(t *testing.T) {
+	// Given
+	r := NewRouterBuilder(nil).
+		WithPerHostDefaults(func(string) []failsafe.Policy[*http.Response] {
+			return []failsafe.Policy[*http.Response]{circuitbreaker.WithDefaults[*http.Response]()}
+		}).
+		Build().(*router)
+	first := r.hostRoundTripperFor("host-0")
+	second := r.hostRoundTripperFor("host-1")
+
+	// When
+	for i := 2; i <= maxHostDefaults; i++ {
+		r.hostRoundTripperFor(fmt.Sprintf("host-%d", i))
+		if i == maxHostDefaults/2 {
+			// Request the first host again so that it's retained
+			r.hostRoundTripperFor("host-0")
+		}
+	}
+
+	// Then
+	assert.Len(t, r.hostDefaults, maxHostDefaults)
+	assert.Equal(t, maxHostDefaults, r.hosts.Len())
+	assert.Same(t, first, r.hostRoundTripperFor("host-0"))
+	assert.NotContains(t, r.hostDefaults, "host-1")
+	assert.NotSame(t, second, r.hostRoundTripperFor("host-1"))
+}
+
+func TestRequestHostname(t *testing.T) {
+	for host, expected := range map[string]string{
+		"example.com":      "example.com",
+		"example.com:8080": "example.com",
+		"[::1]:8080":       "::1",
+		"[::1]":            "::1",
+	} {
+		assert.Equal(t, expected, requestHostname(&http.Request{URL: &url.URL{Host: host}}))
+	}
+}
+
+func openCircuitBreaker() circuitbreaker.CircuitBreaker[*http.Response] {
+	cb := circuitbreaker.WithDefaults[*http.Response]()
+	cb.Open()
+	return cb
+}
+
+func assertRouterSuccess(t *testing.T, client http.Client, url string) {
+	resp, err := client.Get(url)
+	assert.NoError(t, err)
+	if resp != nil {
+		assert.Equal(t, 200, resp.StatusCode)
+		resp.Body.Close()
+	}
+}
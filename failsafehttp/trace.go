@@ -0,0 +1,161 @@
+package failsafehttp
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+type key int
+
+// traceKey is a key to use with a Context that stores a traceRecorder.
+const traceKey key = 0
+
+// AttemptTrace contains timings for an HTTP request attempt, which can be used to determine whether an attempt's time
+// was spent setting up a connection or waiting for a server. Timings are 0 for phases that did not occur, such as when
+// a connection was reused, or that have not completed yet.
+type AttemptTrace struct {
+	// Attempt is the execution attempt number that the trace is for.
+	Attempt int
+	// IsHedge indicates whether the attempt was a hedge.
+	IsHedge bool
+	// StartTime is the time that the attempt started at.
+	StartTime time.Time
+	// DNS is the time spent resolving the host.
+	DNS time.Duration
+	// Connect is the time spent establishing a TCP connection.
+	Connect time.Duration
+	// TLS is the time spent performing a TLS handshake.
+	TLS time.Duration
+	// FirstByte is the time from the start of the attempt until the first byte of the response was received.
+	FirstByte time.Duration
+	// Total is the time from the start of the attempt until a response or error was returned, else 0 if the attempt is in
+	// progress.
+	Total time.Duration
+	// ConnReused indicates whether the attempt reused a previously established connection.
+	ConnReused bool
+}
+
+// traceRecorder records AttemptTraces for the attempts of an execution.
+type traceRecorder struct {
+	mtx    sync.Mutex
+	traces []*AttemptTrace
+}
+
+// WithTracing returns a copy of the ctx that enables tracing of HTTP request attempts via net/http/httptrace. Tracing is
+// performed for requests made by NewRoundTripper or Request.Do that have the resulting ctx, or for executions whose
+// Executor has the resulting ctx. Traces can be accessed via Traces, including from within event listeners such as
+// OnRetry, OnHedge, and OnTimeoutExceeded.
+//
+// A new ctx should be created via WithTracing for each request, since traces are recorded for every attempt that uses
+// the ctx.
+func WithTracing(ctx context.Context) context.Context {
+	return context.WithValue(ctx, traceKey, &traceRecorder{})
+}
+
+// Traces returns traces for the HTTP request attempts of an execution that have started so far, in the order they were
+// started, else nil if tracing is not enabled for the execution. The exec may be an Execution, or an ExecutionEvent or
+// ExecutionDoneEvent from an event listener. See WithTracing.
+func Traces(exec failsafe.ExecutionStats) []AttemptTrace {
+	switch e := exec.(type) {
+	case failsafe.ExecutionEvent[*http.Response]:
+		exec = e.ExecutionAttempt
+	case failsafe.ExecutionDoneEvent[*http.Response]:
+		exec = e.ExecutionStats
+	}
+	ctxExec, ok := exec.(interface{ Context() context.Context })
+	if !ok {
+		return nil
+	}
+	recorder := recorderFrom(ctxExec.Context())
+	if recorder == nil {
+		return nil
+	}
+	recorder.mtx.Lock()
+	defer recorder.mtx.Unlock()
+	traces := make([]AttemptTrace, len(recorder.traces))
+	for i, trace := range recorder.traces {
+		traces[i] = *trace
+	}
+	return traces
+}
+
+func recorderFrom(ctx context.Context) *traceRecorder {
+	recorder, _ := ctx.Value(traceKey).(*traceRecorder)
+	return recorder
+}
+
+// executorFor returns the executor to use for the request, which uses the request's ctx, if one was provided, so that
+// canceling the request cancels its execution. Requests without a ctx use any ctx that the executor was configured with.
+// The request is stored in the execution's ctx so that policies can determine whether it's safe to retry, and the tracker
+// observes the execution so that retried responses are discarded.
+func executorFor(executor failsafe.Executor[*http.Response], request *http.Request, tracker *responseTracker) failsafe.Executor[*http.Response] {
+	if ctx := request.Context(); ctx != context.Background() {
+		executor = executor.WithContext(ctx)
+	}
+	return executor.WithObserver(requestObserver{request}).WithObserver(tracker)
+}
+
+// traceAttempt returns a copy of the request that records an AttemptTrace for the exec, if tracing is enabled, along with a
+// func that should be called when the attempt is done.
+func traceAttempt(exec failsafe.Execution[*http.Response], request *http.Request) (*http.Request, func()) {
+	recorder := recorderFrom(exec.Context())
+	if recorder == nil {
+		return request, func() {}
+	}
+
+	trace := &AttemptTrace{
+		Attempt:   exec.Attempts(),
+		IsHedge:   exec.IsHedge(),
+		StartTime: time.Now(),
+	}
+	recorder.mtx.Lock()
+	recorder.traces = append(recorder.traces, trace)
+	recorder.mtx.Unlock()
+
+	var dnsStart, connectStart, tlsStart time.Time
+	record := func(fn func()) {
+		recorder.mtx.Lock()
+		defer recorder.mtx.Unlock()
+		fn()
+	}
+	clientTrace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			record(func() { dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			record(func() { trace.DNS = time.Since(dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			record(func() {
+				if connectStart.IsZero() {
+					connectStart = time.Now()
+				}
+			})
+		},
+		ConnectDone: func(string, string, error) {
+			record(func() { trace.Connect = time.Since(connectStart) })
+		},
+		TLSHandshakeStart: func() {
+			record(func() { tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			record(func() { trace.TLS = time.Since(tlsStart) })
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			record(func() { trace.ConnReused = info.Reused })
+		},
+		GotFirstResponseByte: func() {
+			record(func() { trace.FirstByte = time.Since(trace.StartTime) })
+		},
+	}
+	ctx := httptrace.WithClientTrace(request.Context(), clientTrace)
+	return request.WithContext(ctx), func() {
+		record(func() { trace.Total = time.Since(trace.StartTime) })
+	}
+}
@@ -0,0 +1,96 @@
+package failsafehttp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// Tests that traces for each attempt are available to listeners.
+func TestTracesWithRetries(t *testing.T) {
+	// Given
+	server := testutil.MockFlakyServer(2, 503, 0, "foo")
+	defer server.Close()
+	var mtx sync.Mutex
+	var retryTraces [][]AttemptTrace
+	rp := RetryPolicyBuilder().
+		OnRetry(func(e failsafe.ExecutionEvent[*http.Response]) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			retryTraces = append(retryTraces, Traces(e))
+		}).
+		Build()
+	client := http.Client{Transport: NewRoundTripper(&http.Transport{}, rp)}
+	req, _ := http.NewRequestWithContext(WithTracing(context.Background()), http.MethodGet, server.URL, nil)
+
+	// When
+	resp, err := client.Do(req)
+
+	// Then
+	assert.NoError(t, err)
+	resp.Body.Close()
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Len(t, retryTraces, 2)
+	assert.Len(t, retryTraces[0], 1)
+	assert.Len(t, retryTraces[1], 2)
+
+	first := retryTraces[0][0]
+	assert.Equal(t, 1, first.Attempt)
+	assert.False(t, first.ConnReused)
+	assert.Greater(t, first.Connect, time.Duration(0))
+	assert.Greater(t, first.FirstByte, time.Duration(0))
+	assert.GreaterOrEqual(t, first.Total, first.FirstByte)
+	assert.Equal(t, 2, retryTraces[1][1].Attempt)
+	assert.True(t, retryTraces[1][1].ConnReused)
+}
+
+// Tests that traces for an attempt that is in progress are available to a timeout listener.
+func TestTracesWithTimeout(t *testing.T) {
+	// Given
+	server := testutil.MockDelayedResponse(200, "foo", time.Second)
+	defer server.Close()
+	tracesChan := make(chan []AttemptTrace, 1)
+	to := timeout.Builder[*http.Response](50 * time.Millisecond).
+		OnTimeoutExceeded(func(e failsafe.ExecutionDoneEvent[*http.Response]) {
+			tracesChan <- Traces(e)
+		}).
+		Build()
+	req, _ := http.NewRequestWithContext(WithTracing(context.Background()), http.MethodGet, server.URL, nil)
+
+	// When
+	_, err := NewRequest(req, http.DefaultClient, to).Do()
+
+	// Then
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	traces := <-tracesChan
+	assert.Len(t, traces, 1)
+	assert.Equal(t, time.Duration(0), traces[0].FirstByte)
+}
+
+func TestTracesWithoutTracing(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(200, "foo")
+	defer server.Close()
+	var traces []AttemptTrace
+	executor := failsafe.NewExecutor[*http.Response]().OnDone(func(e failsafe.ExecutionDoneEvent[*http.Response]) {
+		traces = Traces(e)
+	})
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	// When
+	resp, err := NewRequestWithExecutor(req, http.DefaultClient, executor).Do()
+
+	// Then
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Nil(t, traces)
+}
@@ -0,0 +1,294 @@
+package failsafenet
+
+import (
+	"container/list"
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+)
+
+// DialerBuilder builds Dialer instances that establish connections via policies, such as a RetryPolicy or Timeout.
+//
+// When dialing an address whose host is a name, the name is resolved first, and each connection attempt, including
+// retries and hedges, dials one of the resolved addresses. Addresses are ordered by alternating between IPv6 and IPv4,
+// and attempts rotate through them, so that retries fail over to other addresses.
+//
+// This type is not concurrency safe.
+type DialerBuilder interface {
+	// WithDialFunc configures the dialFunc to dial each resolved address with, such as the DialContext of a net.Dialer
+	// that has a KeepAlive configured. Defaults to the DialContext of a zero net.Dialer.
+	WithDialFunc(dialFunc func(ctx context.Context, network, address string) (net.Conn, error)) DialerBuilder
+
+	// WithResolver configures the resolver to resolve host names with. Defaults to net.DefaultResolver.
+	WithResolver(resolver Resolver) DialerBuilder
+
+	// WithCircuitBreakers configures a function that creates a CircuitBreaker for each distinct address that is dialed.
+	// The cbFunc is called once per address, and the resulting CircuitBreaker handles every attempt to dial the address,
+	// so that failures of one address don't prevent dialing other addresses for the same host. Attempts for an address
+	// whose CircuitBreaker is open fail with circuitbreaker.ErrOpen. Attempts that are canceled, such as losing Happy
+	// Eyeballs attempts, are not recorded by the CircuitBreakers. CircuitBreakers are retained for up to 1024 of the most
+	// recently dialed addresses, and are created again via the cbFunc if an address is dialed after being evicted.
+	WithCircuitBreakers(cbFunc func(address string) circuitbreaker.CircuitBreaker[net.Conn]) DialerBuilder
+
+	// WithHappyEyeballs enables racing connection attempts across resolved addresses, similar to Happy Eyeballs in RFC
+	// 8305. When a host resolves to multiple addresses, and an attempt doesn't complete within the delay, a hedged attempt
+	// is started against the next address, up to one attempt per address. If an attempt fails before the delay, the next
+	// attempt is started immediately. The first connection that's established is returned, and any others are closed. RFC
+	// 8305 recommends a delay of 250 milliseconds.
+	WithHappyEyeballs(delay time.Duration) DialerBuilder
+
+	// Build returns a new Dialer using the builder's configuration.
+	Build() *Dialer
+}
+
+// Resolver resolves host names to IP addresses. This is implemented by net.Resolver.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type dialerConfig struct {
+	policies          []failsafe.Policy[net.Conn]
+	dialFunc          func(ctx context.Context, network, address string) (net.Conn, error)
+	resolver          Resolver
+	cbFunc            func(address string) circuitbreaker.CircuitBreaker[net.Conn]
+	happyEyeballs     bool
+	happyEyeballDelay time.Duration
+}
+
+var _ DialerBuilder = &dialerConfig{}
+
+// Dialer establishes connections via policies. Its DialContext can be used as an http.Transport's DialContext, or with
+// database drivers that accept a dial function.
+//
+// This type is concurrency safe.
+type Dialer struct {
+	config   *dialerConfig
+	executor failsafe.Executor[net.Conn]
+
+	mtx                    sync.Mutex
+	happyEyeballsExecutors map[int]failsafe.Executor[net.Conn] // Guarded by mtx. Keyed by the number of addresses.
+	circuitBreakers        map[string]*list.Element            // Guarded by mtx
+	addresses              *list.List                          // Guarded by mtx. Addresses with CircuitBreakers, most recently dialed first.
+}
+
+// maxCircuitBreakers is the max number of addresses to retain CircuitBreakers for.
+const maxCircuitBreakers = 1024
+
+// addressCircuitBreaker is a CircuitBreaker for an address, along with an executor for it.
+type addressCircuitBreaker struct {
+	address  string
+	executor failsafe.Executor[net.Conn]
+}
+
+// NewDialer returns a new Dialer that establishes connections via the policies. The policies are composed around each
+// dial, including any hedged attempts, and will handle connections in reverse order.
+func NewDialer(policies ...failsafe.Policy[net.Conn]) *Dialer {
+	return NewDialerBuilder(policies...).Build()
+}
+
+// NewDialerBuilder returns a new DialerBuilder that will build Dialers that establish connections via the policies. The
+// policies are composed around each dial, including any hedged attempts, and will handle connections in reverse order.
+func NewDialerBuilder(policies ...failsafe.Policy[net.Conn]) DialerBuilder {
+	return &dialerConfig{
+		policies: policies,
+		dialFunc: (&net.Dialer{}).DialContext,
+		resolver: net.DefaultResolver,
+	}
+}
+
+func (c *dialerConfig) WithDialFunc(dialFunc func(ctx context.Context, network, address string) (net.Conn, error)) DialerBuilder {
+	c.dialFunc = dialFunc
+	return c
+}
+
+func (c *dialerConfig) WithResolver(resolver Resolver) DialerBuilder {
+	c.resolver = resolver
+	return c
+}
+
+func (c *dialerConfig) WithCircuitBreakers(cbFunc func(address string) circuitbreaker.CircuitBreaker[net.Conn]) DialerBuilder {
+	c.cbFunc = cbFunc
+	return c
+}
+
+func (c *dialerConfig) WithHappyEyeballs(delay time.Duration) DialerBuilder {
+	c.happyEyeballs = true
+	c.happyEyeballDelay = delay
+	return c
+}
+
+func (c *dialerConfig) Build() *Dialer {
+	config := *c
+	config.policies = slices.Clone(c.policies)
+	return &Dialer{
+		config:                 &config,
+		executor:               failsafe.NewExecutor(config.policies...),
+		happyEyeballsExecutors: make(map[int]failsafe.Executor[net.Conn]),
+		circuitBreakers:        make(map[string]*list.Element),
+		addresses:              list.New(),
+	}
+}
+
+// Dial connects to the address on the named network. See DialContext.
+func (d *Dialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialContext connects to the address on the named network via the Dialer's policies. If the address's host is a name,
+// it's resolved before any connection attempts are made. Addresses on unix networks are dialed as is.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	addresses, err := d.resolve(ctx, network, address)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+
+	executor := d.executor
+	if d.config.happyEyeballs && len(addresses) > 1 {
+		executor = d.happyEyeballsExecutor(len(addresses))
+	}
+	return executor.WithContext(ctx).WithTargets(addresses...).GetWithExecution(func(exec failsafe.Execution[net.Conn]) (net.Conn, error) {
+		return d.dialAddress(exec.Context(), network, exec.Target())
+	})
+}
+
+// dialAddress dials a resolved address, via the address's CircuitBreaker if any.
+func (d *Dialer) dialAddress(ctx context.Context, network, address string) (net.Conn, error) {
+	executor := d.circuitBreakerExecutor(address)
+	if executor == nil {
+		return d.config.dialFunc(ctx, network, address)
+	}
+	return executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[net.Conn]) (net.Conn, error) {
+		return d.config.dialFunc(exec.Context(), network, address)
+	})
+}
+
+// circuitBreakerExecutor returns an executor for the address's CircuitBreaker, creating them if needed, else nil if per
+// address CircuitBreakers are not configured. The CircuitBreaker for the least recently dialed address is evicted if
+// there are more than maxCircuitBreakers.
+func (d *Dialer) circuitBreakerExecutor(address string) failsafe.Executor[net.Conn] {
+	if d.config.cbFunc == nil {
+		return nil
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if element, ok := d.circuitBreakers[address]; ok {
+		d.addresses.MoveToFront(element)
+		return element.Value.(*addressCircuitBreaker).executor
+	}
+	executor := failsafe.NewExecutor[net.Conn](d.config.cbFunc(address))
+	d.circuitBreakers[address] = d.addresses.PushFront(&addressCircuitBreaker{address: address, executor: executor})
+	if d.addresses.Len() > maxCircuitBreakers {
+		oldest := d.addresses.Remove(d.addresses.Back()).(*addressCircuitBreaker)
+		delete(d.circuitBreakers, oldest.address)
+	}
+	return executor
+}
+
+// happyEyeballsExecutor returns an executor that performs Happy Eyeballs for the number of addresses, along with the
+// Dialer's policies, creating it if needed.
+func (d *Dialer) happyEyeballsExecutor(addresses int) failsafe.Executor[net.Conn] {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	executor, ok := d.happyEyeballsExecutors[addresses]
+	if !ok {
+		executor = failsafe.NewExecutor(append(slices.Clone(d.config.policies), d.happyEyeballsPolicy(addresses))...)
+		d.happyEyeballsExecutors[addresses] = executor
+	}
+	return executor
+}
+
+// happyEyeballsPolicy returns a HedgePolicy that races an attempt for each of the addresses, returning the first
+// connection that's established and closing any others.
+func (d *Dialer) happyEyeballsPolicy(addresses int) hedgepolicy.HedgePolicy[net.Conn] {
+	return hedgepolicy.BuilderWithDelay[net.Conn](d.config.happyEyeballDelay).
+		WithMaxHedges(addresses - 1).
+		HedgeOnFailure().
+		CancelIf(func(_ net.Conn, err error) bool {
+			return err == nil
+		}).
+		OnDiscarded(func(conn net.Conn, _ error) {
+			if conn != nil {
+				conn.Close()
+			}
+		}).
+		Build()
+}
+
+// resolve returns the addresses to dial for the address, resolving its host if it's a name. Addresses on networks that
+// are not IP based, such as unix sockets, are not resolved.
+func (d *Dialer) resolve(ctx context.Context, network, address string) ([]string, error) {
+	switch network {
+	case "unix", "unixgram", "unixpacket":
+		return []string{address}, nil
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if host == "" || net.ParseIP(host) != nil {
+		return []string{address}, nil
+	}
+	ips, err := d.config.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips = filterIPs(network, ips)
+	if len(ips) == 0 {
+		return nil, &net.AddrError{Err: "no suitable address found", Addr: host}
+	}
+	addresses := make([]string, 0, len(ips))
+	for _, ip := range interleave(ips) {
+		addresses = append(addresses, net.JoinHostPort(ip.String(), port))
+	}
+	return addresses, nil
+}
+
+// filterIPs returns the ips that can be dialed on the network, such as only IPv4 addresses for tcp4.
+func filterIPs(network string, ips []net.IPAddr) []net.IPAddr {
+	ipv4Only := strings.HasSuffix(network, "4")
+	ipv6Only := strings.HasSuffix(network, "6")
+	if !ipv4Only && !ipv6Only {
+		return ips
+	}
+	var result []net.IPAddr
+	for _, ip := range ips {
+		if isIPv4(ip) == ipv4Only {
+			result = append(result, ip)
+		}
+	}
+	return result
+}
+
+// interleave orders the ips by alternating between address families, starting with the family of the first ip, as
+// recommended by RFC 8305.
+func interleave(ips []net.IPAddr) []net.IPAddr {
+	var primary, secondary []net.IPAddr
+	for _, ip := range ips {
+		if isIPv4(ip) == isIPv4(ips[0]) {
+			primary = append(primary, ip)
+		} else {
+			secondary = append(secondary, ip)
+		}
+	}
+	result := make([]net.IPAddr, 0, len(ips))
+	for i := 0; i < len(primary) || i < len(secondary); i++ {
+		if i < len(primary) {
+			result = append(result, primary[i])
+		}
+		if i < len(secondary) {
+			result = append(result, secondary[i])
+		}
+	}
+	return result
+}
+
+func isIPv4(ip net.IPAddr) bool {
+	return ip.IP.To4() != nil
+}
@@ -0,0 +1,421 @@
+package failsafenet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// Tests that retries fail over to other resolved addresses.
+func TestDialWithRetries(t *testing.T) {
+	// Given
+	listener, port := listen(t)
+	defer listener.Close()
+	dialer := NewDialerBuilder(retrypolicy.WithDefaults[net.Conn]()).
+		WithResolver(staticResolver("127.0.0.2", "127.0.0.1")).
+		WithDialFunc(failDialing("127.0.0.2")).
+		Build()
+
+	// When
+	conn, err := dialer.DialContext(context.Background(), "tcp", net.JoinHostPort("example.test", port))
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String())
+	conn.Close()
+}
+
+func TestDialWithIPAddress(t *testing.T) {
+	// Given
+	listener, _ := listen(t)
+	defer listener.Close()
+	dialer := NewDialerBuilder().
+		WithResolver(staticResolver()).
+		Build()
+
+	// When
+	conn, err := dialer.Dial("tcp", listener.Addr().String())
+
+	// Then
+	assert.NoError(t, err)
+	conn.Close()
+}
+
+// Tests that unix socket addresses are dialed without being resolved.
+func TestDialWithUnixSocket(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "test.sock")
+	listener, err := net.Listen("unix", path)
+	assert.NoError(t, err)
+	defer listener.Close()
+	dialer := NewDialerBuilder(retrypolicy.WithDefaults[net.Conn]()).
+		WithResolver(staticResolver()).
+		Build()
+
+	// When
+	conn, err := dialer.Dial("unix", path)
+
+	// Then
+	assert.NoError(t, err)
+	conn.Close()
+}
+
+func TestDialWithResolutionError(t *testing.T) {
+	// Given
+	dnsErr := &net.DNSError{Err: "no such host", Name: "example.test", IsNotFound: true}
+	dialer := NewDialerBuilder(retrypolicy.WithDefaults[net.Conn]()).
+		WithResolver(resolverFunc(func(context.Context, string) ([]net.IPAddr, error) {
+			return nil, dnsErr
+		})).
+		Build()
+
+	// When
+	conn, err := dialer.DialContext(context.Background(), "tcp", "example.test:80")
+
+	// Then
+	assert.Nil(t, conn)
+	var opErr *net.OpError
+	assert.ErrorAs(t, err, &opErr)
+	assert.Equal(t, "dial", opErr.Op)
+	assert.ErrorIs(t, err, dnsErr)
+}
+
+// Tests that each address has its own CircuitBreaker, and that dials fail over from addresses whose breaker is open.
+func TestDialWithCircuitBreakers(t *testing.T) {
+	// Given
+	listener, port := listen(t)
+	defer listener.Close()
+	var mtx sync.Mutex
+	breakers := make(map[string]circuitbreaker.CircuitBreaker[net.Conn])
+	var badDials atomic.Int32
+	dialer := NewDialerBuilder(retrypolicy.WithDefaults[net.Conn]()).
+		WithResolver(staticResolver("127.0.0.2", "127.0.0.1")).
+		WithDialFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			if address == net.JoinHostPort("127.0.0.2", port) {
+				badDials.Add(1)
+			}
+			return failDialing("127.0.0.2")(ctx, network, address)
+		}).
+		WithCircuitBreakers(func(address string) circuitbreaker.CircuitBreaker[net.Conn] {
+			mtx.Lock()
+			defer mtx.Unlock()
+			cb := circuitbreaker.Builder[net.Conn]().WithDelay(time.Minute).Build()
+			breakers[address] = cb
+			return cb
+		}).
+		Build()
+	address := net.JoinHostPort("example.test", port)
+
+	// When
+	for i := 0; i < 3; i++ {
+		conn, err := dialer.DialContext(context.Background(), "tcp", address)
+		assert.NoError(t, err)
+		conn.Close()
+	}
+
+	// Then
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.True(t, breakers[net.JoinHostPort("127.0.0.2", port)].IsOpen())
+	assert.True(t, breakers[net.JoinHostPort("127.0.0.1", port)].IsClosed())
+	assert.Equal(t, int32(1), badDials.Load())
+}
+
+// Tests that a hedged attempt against another address is started when the first attempt is slow.
+func TestDialWithHappyEyeballs(t *testing.T) {
+	// Given
+	listener, port := listen(t)
+	defer listener.Close()
+	dialer := NewDialerBuilder(timeout.With[net.Conn](time.Second)).
+		WithResolver(staticResolver("127.0.0.2", "127.0.0.1")).
+		WithDialFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			if address == net.JoinHostPort("127.0.0.2", port) {
+				// Simulate an unresponsive address
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		}).
+		WithHappyEyeballs(50 * time.Millisecond).
+		Build()
+
+	// When
+	var conn net.Conn
+	var err error
+	elapsed := testutil.Timed(func() {
+		conn, err = dialer.DialContext(context.Background(), "tcp", net.JoinHostPort("example.test", port))
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String())
+	assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond)
+	assert.Less(t, elapsed, time.Second)
+	conn.Close()
+}
+
+// Tests that connections from attempts that lose a happy eyeballs race are closed.
+func TestDialWithHappyEyeballsShouldCloseLosingConns(t *testing.T) {
+	// Given
+	listener, port := listen(t)
+	defer listener.Close()
+	slowConn := make(chan *closeRecordingConn, 1)
+	dialer := NewDialerBuilder().
+		WithResolver(staticResolver("127.0.0.1", "::1")).
+		WithDialFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			if address == net.JoinHostPort("127.0.0.1", port) {
+				// Complete after the hedge, ignoring cancellation
+				time.Sleep(100 * time.Millisecond)
+				conn, err := (&net.Dialer{}).DialContext(context.Background(), network, address)
+				if err != nil {
+					return nil, err
+				}
+				recordingConn := &closeRecordingConn{Conn: conn}
+				slowConn <- recordingConn
+				return recordingConn, nil
+			}
+			// The listener only accepts IPv4 connections, so dial it directly for the hedged attempt
+			return (&net.Dialer{}).DialContext(ctx, network, listener.Addr().String())
+		}).
+		WithHappyEyeballs(10 * time.Millisecond).
+		Build()
+
+	// When
+	conn, err := dialer.DialContext(context.Background(), "tcp", net.JoinHostPort("example.test", port))
+
+	// Then
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, isSlowConn := conn.(*closeRecordingConn)
+	assert.False(t, isSlowConn)
+	assert.Eventually(t, func() bool {
+		select {
+		case c := <-slowConn:
+			slowConn <- c
+			return c.closed.Load()
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
+
+// Tests that attempts that lose a happy eyeballs race are not recorded as failures by their address's CircuitBreaker.
+func TestDialWithHappyEyeballsShouldNotRecordCanceledAttempts(t *testing.T) {
+	// Given
+	listener, port := listen(t)
+	defer listener.Close()
+	slowAddress := net.JoinHostPort("127.0.0.2", port)
+	slowDialDone := make(chan struct{})
+	var mtx sync.Mutex
+	breakers := make(map[string]circuitbreaker.CircuitBreaker[net.Conn])
+	dialer := NewDialerBuilder().
+		WithResolver(staticResolver("127.0.0.2", "127.0.0.1")).
+		WithDialFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			if address == slowAddress {
+				// Simulate an unresponsive address
+				defer close(slowDialDone)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		}).
+		WithCircuitBreakers(func(address string) circuitbreaker.CircuitBreaker[net.Conn] {
+			mtx.Lock()
+			defer mtx.Unlock()
+			cb := circuitbreaker.Builder[net.Conn]().HandleIf(func(_ net.Conn, err error) bool {
+				return err != nil
+			}).WithDelay(time.Minute).Build()
+			breakers[address] = cb
+			return cb
+		}).
+		WithHappyEyeballs(10 * time.Millisecond).
+		Build()
+
+	// When
+	conn, err := dialer.DialContext(context.Background(), "tcp", net.JoinHostPort("example.test", port))
+	<-slowDialDone
+
+	// Then
+	assert.NoError(t, err)
+	conn.Close()
+	mtx.Lock()
+	slowBreaker := breakers[slowAddress]
+	mtx.Unlock()
+	assert.Never(t, slowBreaker.IsOpen, 50*time.Millisecond, 10*time.Millisecond)
+	assert.Equal(t, uint(0), slowBreaker.Metrics().Executions())
+}
+
+// Tests that an attempt that fails quickly starts an attempt against the next address without waiting for the delay.
+func TestDialWithHappyEyeballsShouldAttemptNextAddressAfterFailure(t *testing.T) {
+	// Given
+	listener, port := listen(t)
+	defer listener.Close()
+	dialer := NewDialerBuilder().
+		WithResolver(staticResolver("127.0.0.2", "127.0.0.1")).
+		WithDialFunc(failDialing("127.0.0.2")).
+		WithHappyEyeballs(time.Second).
+		Build()
+
+	// When
+	var conn net.Conn
+	var err error
+	elapsed := testutil.Timed(func() {
+		conn, err = dialer.DialContext(context.Background(), "tcp", net.JoinHostPort("example.test", port))
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String())
+	assert.Less(t, elapsed, 500*time.Millisecond)
+	conn.Close()
+}
+
+// Tests that CircuitBreakers are only retained for the most recently dialed addresses.
+func TestDialerShouldEvictCircuitBreakers(t *testing.T) {
+	// Given
+	dialer := NewDialerBuilder().
+		WithCircuitBreakers(func(string) circuitbreaker.CircuitBreaker[net.Conn] {
+			return circuitbreaker.WithDefaults[net.Conn]()
+		}).
+		Build()
+	first := dialer.circuitBreakerExecutor("address-0")
+	second := dialer.circuitBreakerExecutor("address-1")
+
+	// When
+	for i := 2; i <= maxCircuitBreakers; i++ {
+		dialer.circuitBreakerExecutor(fmt.Sprintf("address-%d", i))
+		if i == maxCircuitBreakers/2 {
+			// Dial the first address again so that it's retained
+			dialer.circuitBreakerExecutor("address-0")
+		}
+	}
+
+	// Then
+	assert.Len(t, dialer.circuitBreakers, maxCircuitBreakers)
+	assert.Equal(t, maxCircuitBreakers, dialer.addresses.Len())
+	assert.Same(t, first, dialer.circuitBreakerExecutor("address-0"))
+	assert.NotContains(t, dialer.circuitBreakers, "address-1")
+	assert.NotSame(t, second, dialer.circuitBreakerExecutor("address-1"))
+}
+
+// Tests that Happy Eyeballs executors are reused for dials with the same number of addresses.
+func TestDialerShouldReuseHappyEyeballsExecutors(t *testing.T) {
+	// Given
+	dialer := NewDialerBuilder().WithHappyEyeballs(time.Millisecond).Build()
+
+	// When / Then
+	assert.Same(t, dialer.happyEyeballsExecutor(2), dialer.happyEyeballsExecutor(2))
+	assert.NotSame(t, dialer.happyEyeballsExecutor(2), dialer.happyEyeballsExecutor(3))
+}
+
+func TestDialerWithHTTPTransport(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("foo"))
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	dialer := NewDialerBuilder(retrypolicy.WithDefaults[net.Conn]()).
+		WithResolver(staticResolver("127.0.0.2", "127.0.0.1")).
+		WithDialFunc(failDialing("127.0.0.2")).
+		Build()
+	client := http.Client{Transport: &http.Transport{DialContext: dialer.DialContext}}
+
+	// When
+	resp, err := client.Get("http://example.test:" + port)
+
+	// Then
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "foo", string(body))
+}
+
+func TestInterleave(t *testing.T) {
+	ips := ipAddrs("2001:db8::1", "2001:db8::2", "2001:db8::3", "192.0.2.1", "192.0.2.2")
+	assert.Equal(t, ipAddrs("2001:db8::1", "192.0.2.1", "2001:db8::2", "192.0.2.2", "2001:db8::3"), interleave(ips))
+}
+
+func TestFilterIPs(t *testing.T) {
+	ips := ipAddrs("2001:db8::1", "192.0.2.1")
+	assert.Equal(t, ips, filterIPs("tcp", ips))
+	assert.Equal(t, ipAddrs("192.0.2.1"), filterIPs("tcp4", ips))
+	assert.Equal(t, ipAddrs("2001:db8::1"), filterIPs("tcp6", ips))
+}
+
+// listen returns a listener on 127.0.0.1 that accepts and holds connections, along with its port.
+func listen(t *testing.T) (net.Listener, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(io.Discard, conn)
+				conn.Close()
+			}()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return listener, port
+}
+
+type resolverFunc func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+func (f resolverFunc) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return f(ctx, host)
+}
+
+// staticResolver returns a Resolver that resolves every host to the ips.
+func staticResolver(ips ...string) Resolver {
+	return resolverFunc(func(context.Context, string) ([]net.IPAddr, error) {
+		return ipAddrs(ips...), nil
+	})
+}
+
+// failDialing returns a dial func that refuses connections to the ip, and dials other addresses.
+func failDialing(ip string) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if host, _, _ := net.SplitHostPort(address); host == ip {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+		}
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+}
+
+func ipAddrs(ips ...string) []net.IPAddr {
+	var result []net.IPAddr
+	for _, ip := range ips {
+		result = append(result, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return result
+}
+
+type closeRecordingConn struct {
+	net.Conn
+	closed atomic.Bool
+}
+
+func (c *closeRecordingConn) Close() error {
+	c.closed.Store(true)
+	return c.Conn.Close()
+}
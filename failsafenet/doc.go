@@ -0,0 +1,2 @@
+// Package failsafenet provides functions that can be used to integrate policies with network connections.
+package failsafenet
@@ -0,0 +1,2 @@
+// Package failsafeotel provides functions that can be used to integrate executions with OpenTelemetry.
+package failsafeotel
@@ -0,0 +1,118 @@
+package failsafeotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/metrics"
+)
+
+// Metrics is a failsafe.Observer that records metrics for executions and policies with OpenTelemetry. Counters and
+// histograms are recorded as executions are performed, and gauges for the CircuitBreakers, Bulkheads, and RateLimiters in
+// a metrics.Registry are reported when metrics are collected. Instruments are named with a failsafe prefix, such as
+// failsafe.retries, and histograms and time based gauges are recorded in seconds. See the metrics package for the
+// available metrics.
+//
+// This type is concurrency safe.
+type Metrics struct {
+	failsafe.Observer
+	registration metric.Registration
+}
+
+// NewMetrics returns a new Metrics that records metrics via the meterProvider, labeling policy metrics with names from
+// the registry. If meterProvider is nil, the global MeterProvider is used. The registry may be nil, in which case policy
+// metrics are labeled with the type of the policy, and no gauges are reported.
+func NewMetrics(meterProvider metric.MeterProvider, registry *metrics.Registry) (*Metrics, error) {
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	meter := meterProvider.Meter(instrumentationName)
+
+	recorder := &recorder{
+		counters:   make(map[metrics.Counter]metric.Int64Counter),
+		histograms: make(map[metrics.Histogram]metric.Float64Histogram),
+	}
+	for _, counter := range metrics.Counters() {
+		instrument, err := meter.Int64Counter(instrumentName(counter.String()), metric.WithDescription(counter.Description()))
+		if err != nil {
+			return nil, err
+		}
+		recorder.counters[counter] = instrument
+	}
+	for _, histogram := range metrics.Histograms() {
+		instrument, err := meter.Float64Histogram(instrumentName(histogram.String()), metric.WithDescription(histogram.Description()), metric.WithUnit("s"))
+		if err != nil {
+			return nil, err
+		}
+		recorder.histograms[histogram] = instrument
+	}
+
+	gauges := make(map[metrics.Gauge]metric.Float64ObservableGauge)
+	var observables []metric.Observable
+	for _, gauge := range metrics.Gauges() {
+		options := []metric.Float64ObservableGaugeOption{metric.WithDescription(gauge.Description())}
+		if gauge.InSeconds() {
+			options = append(options, metric.WithUnit("s"))
+		}
+		instrument, err := meter.Float64ObservableGauge(instrumentName(gauge.String()), options...)
+		if err != nil {
+			return nil, err
+		}
+		gauges[gauge] = instrument
+		observables = append(observables, instrument)
+	}
+	registration, err := meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		registry.CollectGauges(func(gauge metrics.Gauge, labels metrics.Labels, value float64) {
+			observer.ObserveFloat64(gauges[gauge], value, metric.WithAttributes(attributes(labels)...))
+		})
+		return nil
+	}, observables...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metrics{
+		Observer:     metrics.NewObserver(registry, recorder),
+		registration: registration,
+	}, nil
+}
+
+// Unregister stops reporting gauges for the Metrics.
+func (m *Metrics) Unregister() error {
+	return m.registration.Unregister()
+}
+
+// recorder is a metrics.Recorder that records to OpenTelemetry instruments.
+type recorder struct {
+	counters   map[metrics.Counter]metric.Int64Counter
+	histograms map[metrics.Histogram]metric.Float64Histogram
+}
+
+var _ metrics.Recorder = &recorder{}
+
+func (r *recorder) IncrementCounter(ctx context.Context, counter metrics.Counter, labels metrics.Labels) {
+	r.counters[counter].Add(ctx, 1, metric.WithAttributes(attributes(labels)...))
+}
+
+func (r *recorder) RecordHistogram(ctx context.Context, histogram metrics.Histogram, labels metrics.Labels, value float64) {
+	r.histograms[histogram].Record(ctx, value, metric.WithAttributes(attributes(labels)...))
+}
+
+func instrumentName(name string) string {
+	return "failsafe." + name
+}
+
+func attributes(labels metrics.Labels) []attribute.KeyValue {
+	var result []attribute.KeyValue
+	if labels.Policy != "" {
+		result = append(result, PolicyKey.String(labels.Policy))
+	}
+	if labels.Outcome != "" {
+		result = append(result, OutcomeKey.String(labels.Outcome))
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package failsafeotel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/metrics"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+func TestMetrics(t *testing.T) {
+	// Given
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	registry := metrics.NewRegistry()
+	rp := retrypolicy.Builder[bool]().WithDelay(10 * time.Millisecond).Build()
+	cb := circuitbreaker.Builder[bool]().WithFailureThreshold(3).Build()
+	bh := bulkhead.With[bool](2)
+	registry.Register("payments", rp)
+	registry.Register("payments", cb)
+	registry.Register("payments", bh)
+	m, err := NewMetrics(meterProvider, registry)
+	assert.NoError(t, err)
+	stub, _ := testutil.ErrorNTimesThenReturn[bool](testutil.ErrInvalidState, 2, true)
+
+	// When
+	_, err = failsafe.NewExecutor[bool](rp, cb, bh).WithObserver(m).GetWithExecution(stub)
+	assert.NoError(t, err)
+	cb.Open()
+	_, err = failsafe.NewExecutor[bool](cb).WithObserver(m).GetWithExecution(testutil.GetFn(true, nil))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+
+	// Then
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Equal(t, map[string]int64{
+		"success": 1,
+		"failure": 1,
+	}, sums(t, rm, "failsafe.executions", OutcomeKey))
+	assert.Equal(t, map[string]int64{"payments": 2}, sums(t, rm, "failsafe.retries", PolicyKey))
+	assert.Equal(t, map[string]int64{"payments": 1}, sums(t, rm, "failsafe.rejections", PolicyKey))
+
+	retryDelays := find(t, rm, "failsafe.retry_delay").Data.(metricdata.Histogram[float64]).DataPoints
+	assert.Len(t, retryDelays, 1)
+	assert.Equal(t, uint64(2), retryDelays[0].Count)
+	assert.InDelta(t, .02, retryDelays[0].Sum, .001)
+	assert.Equal(t, "s", find(t, rm, "failsafe.attempt_latency").Unit)
+
+	states := find(t, rm, "failsafe.circuit_breaker_state").Data.(metricdata.Gauge[float64]).DataPoints
+	assert.Len(t, states, 1)
+	assert.Equal(t, float64(circuitbreaker.OpenState), states[0].Value)
+	assertPolicy(t, states[0].Attributes, "payments")
+	inFlight := find(t, rm, "failsafe.bulkhead_in_flight").Data.(metricdata.Gauge[float64]).DataPoints
+	assert.Len(t, inFlight, 1)
+	assert.Equal(t, float64(0), inFlight[0].Value)
+}
+
+func TestMetricsUnregister(t *testing.T) {
+	// Given
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	registry := metrics.NewRegistry()
+	registry.Register("foo", circuitbreaker.WithDefaults[any]())
+	m, err := NewMetrics(meterProvider, registry)
+	assert.NoError(t, err)
+
+	// When
+	assert.NoError(t, m.Unregister())
+
+	// Then
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		for _, metric := range scopeMetrics.Metrics {
+			assert.NotEqual(t, "failsafe.circuit_breaker_state", metric.Name)
+		}
+	}
+}
+
+func find(t *testing.T, rm metricdata.ResourceMetrics, name string) metricdata.Metrics {
+	t.Helper()
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		for _, metric := range scopeMetrics.Metrics {
+			if metric.Name == name {
+				return metric
+			}
+		}
+	}
+	assert.Fail(t, "missing metric", name)
+	return metricdata.Metrics{}
+}
+
+// sums returns the values of the sum metric with the name, by the value of the key attribute.
+func sums(t *testing.T, rm metricdata.ResourceMetrics, name string, key attribute.Key) map[string]int64 {
+	result := make(map[string]int64)
+	for _, dataPoint := range find(t, rm, name).Data.(metricdata.Sum[int64]).DataPoints {
+		value, _ := dataPoint.Attributes.Value(key)
+		result[value.AsString()] = dataPoint.Value
+	}
+	return result
+}
+
+func assertPolicy(t *testing.T, attributes attribute.Set, expected string) {
+	value, _ := attributes.Value(PolicyKey)
+	assert.Equal(t, expected, value.AsString())
+}
@@ -0,0 +1,137 @@
+package failsafeotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/metrics"
+)
+
+// The name of the instrumentation scope for spans and metrics created by this package.
+const instrumentationName = "github.com/failsafe-go/failsafe-go/failsafeotel"
+
+// Span names.
+const (
+	ExecutionSpanName = "failsafe.execution"
+	AttemptSpanName   = "failsafe.attempt"
+)
+
+// Attribute keys for spans, span events, and metrics.
+const (
+	// AttemptKey is the attempt number, starting at 1.
+	AttemptKey = attribute.Key("failsafe.attempt")
+	// AttemptsKey is the total number of attempts for an execution.
+	AttemptsKey = attribute.Key("failsafe.attempts")
+	// RetryKey is whether an attempt is a retry.
+	RetryKey = attribute.Key("failsafe.retry")
+	// RetriesKey is the total number of retries for an execution.
+	RetriesKey = attribute.Key("failsafe.retries")
+	// HedgeKey is whether an attempt is a hedge.
+	HedgeKey = attribute.Key("failsafe.hedge")
+	// HedgesKey is the total number of hedges for an execution.
+	HedgesKey = attribute.Key("failsafe.hedges")
+	// TargetKey is the target of an attempt, when targets are configured.
+	TargetKey = attribute.Key("failsafe.target")
+	// PolicyKey is the policy that a span event or metric is for. This is the policy's name in a metrics.Registry, else
+	// its type, such as RetryPolicy.
+	PolicyKey = attribute.Key("failsafe.policy")
+	// DelayKey is the delay in milliseconds before a retry.
+	DelayKey = attribute.Key("failsafe.delay_ms")
+	// ErrorKey is the error that caused a span event.
+	ErrorKey = attribute.Key("failsafe.error")
+	// OutcomeKey is the outcome of an execution or attempt, either success or failure.
+	OutcomeKey = attribute.Key("failsafe.outcome")
+)
+
+// Tracer is a failsafe.Observer that records executions as OpenTelemetry spans. Each execution is recorded as a span,
+// with a child span for each attempt, including retries and hedges. Policy events, such as retries being scheduled,
+// hedges, fallbacks, CircuitBreaker rejections, and timeouts, are recorded as events on the execution's span.
+//
+// The attempt's span is available via the Execution.Context, so that spans created by the execution's fn are children
+// of the attempt's span. Executions performed with a context that contains a span will have their spans created as
+// children of it.
+//
+// This type is concurrency safe.
+type Tracer struct {
+	tracer   trace.Tracer
+	registry *metrics.Registry
+}
+
+var _ failsafe.Observer = &Tracer{}
+
+// NewTracer returns a new Tracer that creates spans via the tracerProvider. If tracerProvider is nil, the global
+// TracerProvider is used. Span events are labeled with the names of policies in the registry, else with the type of the
+// policy if it's not registered. The registry may be nil.
+func NewTracer(tracerProvider trace.TracerProvider, registry *metrics.Registry) *Tracer {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	return &Tracer{
+		tracer:   tracerProvider.Tracer(instrumentationName),
+		registry: registry,
+	}
+}
+
+func (t *Tracer) ExecutionStarted(ctx context.Context) context.Context {
+	ctx, _ = t.tracer.Start(ctx, ExecutionSpanName, trace.WithSpanKind(trace.SpanKindInternal))
+	return ctx
+}
+
+func (t *Tracer) AttemptStarted(ctx context.Context, attempt failsafe.AttemptStats) context.Context {
+	attributes := []attribute.KeyValue{
+		AttemptKey.Int(attempt.Attempts()),
+		RetryKey.Bool(attempt.IsRetry() && !attempt.IsHedge()),
+		HedgeKey.Bool(attempt.IsHedge()),
+	}
+	if target := attempt.Target(); target != "" {
+		attributes = append(attributes, TargetKey.String(target))
+	}
+	ctx, _ = t.tracer.Start(ctx, AttemptSpanName, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attributes...))
+	return ctx
+}
+
+func (t *Tracer) AttemptDone(ctx context.Context, _ failsafe.AttemptStats, err error) {
+	endSpan(trace.SpanFromContext(ctx), err)
+}
+
+func (t *Tracer) PolicyEvent(ctx context.Context, event failsafe.PolicyEvent) {
+	policy := t.registry.Name(event.Source)
+	if policy == "" {
+		policy = event.Policy
+	}
+	attributes := []attribute.KeyValue{
+		PolicyKey.String(policy),
+		AttemptKey.Int(event.Attempts()),
+	}
+	if event.Type == failsafe.RetryScheduled {
+		attributes = append(attributes, DelayKey.Int64(event.Delay.Milliseconds()))
+	}
+	if event.Error != nil {
+		attributes = append(attributes, ErrorKey.String(event.Error.Error()))
+	}
+	trace.SpanFromContext(ctx).AddEvent("failsafe."+event.Type.String(), trace.WithAttributes(attributes...))
+}
+
+func (t *Tracer) ExecutionDone(ctx context.Context, stats failsafe.ExecutionStats, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		AttemptsKey.Int(stats.Attempts()),
+		RetriesKey.Int(stats.Retries()),
+		HedgesKey.Int(stats.Hedges()),
+	)
+	endSpan(span, err)
+}
+
+// endSpan ends the span, recording the err if any.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,236 @@
+package failsafeotel
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/metrics"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+func TestTracerWithRetries(t *testing.T) {
+	// Given
+	exporter, tracer := setup()
+	stub, _ := testutil.ErrorNTimesThenReturn[bool](testutil.ErrInvalidState, 2, true)
+	rp := retrypolicy.Builder[bool]().WithDelay(10 * time.Millisecond).Build()
+
+	// When
+	result, err := failsafe.NewExecutor[bool](rp).WithObserver(tracer).GetWithExecution(stub)
+
+	// Then
+	assert.True(t, result)
+	assert.NoError(t, err)
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 4)
+	executionSpan := spans[3]
+	assert.Equal(t, ExecutionSpanName, executionSpan.Name)
+	assert.Equal(t, codes.Unset, executionSpan.Status.Code)
+	assertAttribute(t, executionSpan.Attributes, AttemptsKey, attribute.IntValue(3))
+	assertAttribute(t, executionSpan.Attributes, RetriesKey, attribute.IntValue(2))
+
+	for i, attemptSpan := range spans[:3] {
+		assert.Equal(t, AttemptSpanName, attemptSpan.Name)
+		assert.Equal(t, executionSpan.SpanContext.SpanID(), attemptSpan.Parent.SpanID())
+		assertAttribute(t, attemptSpan.Attributes, AttemptKey, attribute.IntValue(i+1))
+		assertAttribute(t, attemptSpan.Attributes, RetryKey, attribute.BoolValue(i > 0))
+	}
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, codes.Unset, spans[2].Status.Code)
+
+	assert.Len(t, executionSpan.Events, 2)
+	for i, event := range executionSpan.Events {
+		assert.Equal(t, "failsafe.retry_scheduled", event.Name)
+		assertAttribute(t, event.Attributes, PolicyKey, attribute.StringValue("RetryPolicy"))
+		assertAttribute(t, event.Attributes, AttemptKey, attribute.IntValue(i+1))
+		assertAttribute(t, event.Attributes, DelayKey, attribute.Int64Value(10))
+		assertAttribute(t, event.Attributes, ErrorKey, attribute.StringValue(testutil.ErrInvalidState.Error()))
+	}
+}
+
+func TestTracerWithRejectionsAndFallback(t *testing.T) {
+	// Given
+	exporter, tracer := setup()
+	cb := circuitbreaker.WithDefaults[bool]()
+	cb.Open()
+	fb := fallback.WithResult(true)
+
+	// When
+	result, err := failsafe.NewExecutor[bool](fb, cb).WithObserver(tracer).GetWithExecution(testutil.GetFn(false, nil))
+
+	// Then
+	assert.True(t, result)
+	assert.NoError(t, err)
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, ExecutionSpanName, spans[0].Name)
+	assert.Equal(t, []string{"failsafe.circuit_breaker_rejected", "failsafe.fallback_executed"}, eventNames(spans[0]))
+	assertAttribute(t, spans[0].Events[0].Attributes, ErrorKey, attribute.StringValue(circuitbreaker.ErrOpen.Error()))
+}
+
+// Tests that span events are labeled with the names of registered policies.
+func TestTracerWithRegistry(t *testing.T) {
+	// Given
+	exporter := tracetest.NewInMemoryExporter()
+	registry := metrics.NewRegistry()
+	tracer := NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), registry)
+	cb := circuitbreaker.WithDefaults[bool]()
+	cb.Open()
+	registry.Register("payments", cb)
+	fb := fallback.WithResult(true)
+
+	// When
+	_, err := failsafe.NewExecutor[bool](fb, cb).WithObserver(tracer).GetWithExecution(testutil.GetFn(false, nil))
+
+	// Then
+	assert.NoError(t, err)
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Len(t, spans[0].Events, 2)
+	assertAttribute(t, spans[0].Events[0].Attributes, PolicyKey, attribute.StringValue("payments"))
+	assertAttribute(t, spans[0].Events[1].Attributes, PolicyKey, attribute.StringValue("Fallback"))
+}
+
+func TestTracerWithTimeout(t *testing.T) {
+	// Given
+	exporter, tracer := setup()
+	to := timeout.With[any](10 * time.Millisecond)
+
+	// When
+	err := failsafe.NewExecutor[any](to).WithObserver(tracer).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		<-exec.Canceled()
+		return exec.Context().Err()
+	})
+
+	// Then
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, ExecutionSpanName, spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, timeout.ErrExceeded.Error(), spans[1].Status.Description)
+	assert.Equal(t, []string{"failsafe.timeout_exceeded"}, eventNames(spans[1]))
+}
+
+func TestTracerWithHedges(t *testing.T) {
+	// Given
+	exporter, tracer := setup()
+	hp := hedgepolicy.BuilderWithDelay[bool](10 * time.Millisecond).
+		CancelIf(func(_ bool, err error) bool {
+			return err == nil
+		}).
+		Build()
+
+	// When
+	result, err := failsafe.NewExecutor[bool](hp).WithObserver(tracer).GetWithExecution(func(exec failsafe.Execution[bool]) (bool, error) {
+		if !exec.IsHedge() {
+			<-exec.Canceled()
+			return false, exec.Context().Err()
+		}
+		return true, nil
+	})
+
+	// Then
+	assert.True(t, result)
+	assert.NoError(t, err)
+	// The canceled attempt may complete after the execution
+	assert.Eventually(t, func() bool {
+		return len(exporter.GetSpans()) == 3
+	}, time.Second, 10*time.Millisecond)
+	executionSpans, attemptSpans := partition(exporter.GetSpans())
+	executionSpan := executionSpans[0]
+	assertAttribute(t, executionSpan.Attributes, HedgesKey, attribute.IntValue(1))
+	assert.Equal(t, []string{"failsafe.hedge_started"}, eventNames(executionSpan))
+	var hedgeSpans int
+	for _, span := range attemptSpans {
+		assert.Equal(t, executionSpan.SpanContext.SpanID(), span.Parent.SpanID())
+		for _, attr := range span.Attributes {
+			if attr.Key == HedgeKey && attr.Value.AsBool() {
+				hedgeSpans++
+			}
+		}
+	}
+	assert.Equal(t, 1, hedgeSpans)
+}
+
+// Tests that the attempt's span is propagated to the execution's context, and that the execution's span is a child of
+// the caller's span.
+func TestTracerContextPropagation(t *testing.T) {
+	// Given
+	exporter, tracer := setup()
+	ctx, parentSpan := tracer.tracer.Start(context.Background(), "parent")
+	var attemptSpanContext trace.SpanContext
+
+	// When
+	err := failsafe.NewExecutor[any]().WithContext(ctx).WithObserver(tracer).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		attemptSpanContext = trace.SpanContextFromContext(exec.Context())
+		return nil
+	})
+	parentSpan.End()
+
+	// Then
+	assert.NoError(t, err)
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+	attemptSpan, executionSpan := spans[0], spans[1]
+	assert.Equal(t, attemptSpan.SpanContext, attemptSpanContext)
+	assert.Equal(t, executionSpan.SpanContext.SpanID(), attemptSpan.Parent.SpanID())
+	assert.Equal(t, parentSpan.SpanContext().SpanID(), executionSpan.Parent.SpanID())
+	assert.Equal(t, parentSpan.SpanContext().TraceID(), attemptSpan.SpanContext.TraceID())
+}
+
+func setup() (*tracetest.InMemoryExporter, *Tracer) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return exporter, NewTracer(tracerProvider, nil)
+}
+
+func assertAttribute(t *testing.T, attributes []attribute.KeyValue, key attribute.Key, expected attribute.Value) {
+	t.Helper()
+	for _, attr := range attributes {
+		if attr.Key == key {
+			assert.Equal(t, expected, attr.Value)
+			return
+		}
+	}
+	assert.Fail(t, "missing attribute", key)
+}
+
+// partition returns the execution spans and attempt spans from the spans.
+func partition(spans tracetest.SpanStubs) (executionSpans, attemptSpans []tracetest.SpanStub) {
+	for _, span := range spans {
+		if span.Name == ExecutionSpanName {
+			executionSpans = append(executionSpans, span)
+		} else if span.Name == AttemptSpanName {
+			attemptSpans = append(attemptSpans, span)
+		}
+	}
+	return
+}
+
+// eventNames returns the names of the span's failsafe events.
+func eventNames(span tracetest.SpanStub) []string {
+	var names []string
+	for _, event := range span.Events {
+		if strings.HasPrefix(event.Name, "failsafe.") {
+			names = append(names, event.Name)
+		}
+	}
+	return names
+}
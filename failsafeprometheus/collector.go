@@ -0,0 +1,125 @@
+package failsafeprometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/metrics"
+)
+
+// Label names.
+const (
+	// PolicyLabel is the policy that a metric is for, which is the policy's name in a metrics.Registry, else its type, such
+	// as RetryPolicy.
+	PolicyLabel = "policy"
+	// OutcomeLabel is the outcome of an execution or attempt, either success or failure.
+	OutcomeLabel = "outcome"
+)
+
+// Collector is a prometheus.Collector and failsafe.Observer that records metrics for executions and policies. Counters and
+// histograms are recorded as executions are performed, and gauges for the CircuitBreakers, Bulkheads, and RateLimiters in
+// a metrics.Registry are reported when metrics are collected. Metrics are named with a failsafe prefix, such as
+// failsafe_retries_total, and histograms and time based gauges are recorded in seconds. See the metrics package for the
+// available metrics.
+//
+// This type is concurrency safe.
+type Collector struct {
+	failsafe.Observer
+	registry   *metrics.Registry
+	counters   map[metrics.Counter]*prometheus.CounterVec
+	histograms map[metrics.Histogram]*prometheus.HistogramVec
+	gauges     map[metrics.Gauge]*prometheus.Desc
+}
+
+var _ prometheus.Collector = &Collector{}
+
+// NewCollector returns a new Collector that labels policy metrics with names from the registry. The registry may be nil,
+// in which case policy metrics are labeled with the type of the policy, and no gauges are reported. The Collector must be
+// registered with a prometheus.Registerer, and added to executors via WithObserver.
+func NewCollector(registry *metrics.Registry) *Collector {
+	c := &Collector{
+		registry:   registry,
+		counters:   make(map[metrics.Counter]*prometheus.CounterVec),
+		histograms: make(map[metrics.Histogram]*prometheus.HistogramVec),
+		gauges:     make(map[metrics.Gauge]*prometheus.Desc),
+	}
+	for _, counter := range metrics.Counters() {
+		label := PolicyLabel
+		if counter == metrics.Executions {
+			label = OutcomeLabel
+		}
+		c.counters[counter] = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "failsafe_" + counter.String() + "_total",
+			Help: counter.Description(),
+		}, []string{label})
+	}
+	for _, histogram := range metrics.Histograms() {
+		label := PolicyLabel
+		if histogram == metrics.AttemptLatency {
+			label = OutcomeLabel
+		}
+		c.histograms[histogram] = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "failsafe_" + histogram.String() + "_seconds",
+			Help:    histogram.Description(),
+			Buckets: prometheus.DefBuckets,
+		}, []string{label})
+	}
+	for _, gauge := range metrics.Gauges() {
+		name := "failsafe_" + gauge.String()
+		if gauge.InSeconds() {
+			name += "_seconds"
+		}
+		c.gauges[gauge] = prometheus.NewDesc(name, gauge.Description(), []string{PolicyLabel}, nil)
+	}
+	c.Observer = metrics.NewObserver(registry, &recorder{c})
+	return c
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, counter := range c.counters {
+		counter.Describe(ch)
+	}
+	for _, histogram := range c.histograms {
+		histogram.Describe(ch)
+	}
+	for _, desc := range c.gauges {
+		ch <- desc
+	}
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, counter := range c.counters {
+		counter.Collect(ch)
+	}
+	for _, histogram := range c.histograms {
+		histogram.Collect(ch)
+	}
+	c.registry.CollectGauges(func(gauge metrics.Gauge, labels metrics.Labels, value float64) {
+		ch <- prometheus.MustNewConstMetric(c.gauges[gauge], prometheus.GaugeValue, value, labels.Policy)
+	})
+}
+
+// recorder is a metrics.Recorder that records to a Collector's metrics.
+type recorder struct {
+	collector *Collector
+}
+
+var _ metrics.Recorder = &recorder{}
+
+func (r *recorder) IncrementCounter(_ context.Context, counter metrics.Counter, labels metrics.Labels) {
+	r.collector.counters[counter].WithLabelValues(labelValue(labels)).Inc()
+}
+
+func (r *recorder) RecordHistogram(_ context.Context, histogram metrics.Histogram, labels metrics.Labels, value float64) {
+	r.collector.histograms[histogram].WithLabelValues(labelValue(labels)).Observe(value)
+}
+
+// labelValue returns the value of the single label that a counter or histogram has.
+func labelValue(labels metrics.Labels) string {
+	if labels.Outcome != "" {
+		return labels.Outcome
+	}
+	return labels.Policy
+}
@@ -0,0 +1,109 @@
+package failsafeprometheus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/metrics"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+func TestCollector(t *testing.T) {
+	// Given
+	registry := metrics.NewRegistry()
+	rp := retrypolicy.Builder[bool]().WithDelay(10 * time.Millisecond).Build()
+	cb := circuitbreaker.WithDefaults[bool]()
+	registry.Register("payments", rp)
+	registry.Register("payments", cb)
+	collector := NewCollector(registry)
+	promRegistry := prometheus.NewPedanticRegistry()
+	promRegistry.MustRegister(collector)
+	stub, _ := testutil.ErrorNTimesThenReturn[bool](testutil.ErrInvalidState, 2, true)
+
+	// When
+	_, err := failsafe.NewExecutor[bool](rp).WithObserver(collector).GetWithExecution(stub)
+	assert.NoError(t, err)
+	cb.Open()
+	_, err = failsafe.NewExecutor[bool](cb).WithObserver(collector).GetWithExecution(testutil.GetFn(true, nil))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+
+	// Then
+	expected := `
+# HELP failsafe_executions_total The number of executions.
+# TYPE failsafe_executions_total counter
+failsafe_executions_total{outcome="failure"} 1
+failsafe_executions_total{outcome="success"} 1
+# HELP failsafe_retries_total The number of retries that were scheduled.
+# TYPE failsafe_retries_total counter
+failsafe_retries_total{policy="payments"} 2
+# HELP failsafe_rejections_total The number of attempts that were rejected.
+# TYPE failsafe_rejections_total counter
+failsafe_rejections_total{policy="payments"} 1
+# HELP failsafe_circuit_breaker_state The state of a circuit breaker, where 0 is closed, 1 is open, and 2 is half-open.
+# TYPE failsafe_circuit_breaker_state gauge
+failsafe_circuit_breaker_state{policy="payments"} 1
+`
+	assert.NoError(t, promtestutil.GatherAndCompare(promRegistry, strings.NewReader(expected),
+		"failsafe_executions_total", "failsafe_retries_total", "failsafe_rejections_total", "failsafe_circuit_breaker_state"))
+	count, err := promtestutil.GatherAndCount(promRegistry, "failsafe_retry_delay_seconds", "failsafe_attempt_latency_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+// Tests that policies that are not registered are labeled with their type.
+func TestCollectorWithoutRegistry(t *testing.T) {
+	// Given
+	collector := NewCollector(nil)
+	promRegistry := prometheus.NewPedanticRegistry()
+	promRegistry.MustRegister(collector)
+
+	// When
+	err := failsafe.NewExecutor[any](timeout.With[any](10 * time.Millisecond)).WithObserver(collector).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		<-exec.Canceled()
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	expected := `
+# HELP failsafe_timeouts_total The number of timeouts that were exceeded.
+# TYPE failsafe_timeouts_total counter
+failsafe_timeouts_total{policy="Timeout"} 1
+`
+	assert.NoError(t, promtestutil.GatherAndCompare(promRegistry, strings.NewReader(expected), "failsafe_timeouts_total"))
+}
+
+func TestCollectorGauges(t *testing.T) {
+	// Given
+	registry := metrics.NewRegistry()
+	bh := bulkhead.With[any](3)
+	bh.TryAcquirePermit()
+	bh.TryAcquirePermit()
+	registry.Register("db", bh)
+	registry.Register("api", ratelimiter.SmoothBuilderWithMaxRate[any](time.Second).Build())
+	promRegistry := prometheus.NewPedanticRegistry()
+	promRegistry.MustRegister(NewCollector(registry))
+
+	// When / Then
+	expected := `
+# HELP failsafe_bulkhead_in_flight The number of executions that are in flight within a bulkhead.
+# TYPE failsafe_bulkhead_in_flight gauge
+failsafe_bulkhead_in_flight{policy="db"} 2
+# HELP failsafe_rate_limiter_wait_seconds The time that an execution would currently wait for a rate limiter permit.
+# TYPE failsafe_rate_limiter_wait_seconds gauge
+failsafe_rate_limiter_wait_seconds{policy="api"} 0
+`
+	assert.NoError(t, promtestutil.GatherAndCompare(promRegistry, strings.NewReader(expected),
+		"failsafe_bulkhead_in_flight", "failsafe_rate_limiter_wait_seconds"))
+}
@@ -0,0 +1,2 @@
+// Package failsafeprometheus provides a Prometheus Collector that records metrics for executions and policies.
+package failsafeprometheus
@@ -0,0 +1,123 @@
+package failsafesql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"syscall"
+)
+
+// Classifier returns whether an error from a database driver is transient, meaning that a statement or transaction that
+// failed with it can be retried.
+type Classifier func(err error) bool
+
+var _ Classifier = IsTransient
+
+// IsTransient returns whether the err is a serialization failure, deadlock, or connection error.
+func IsTransient(err error) bool {
+	return IsSerializationFailure(err) || IsDeadlock(err) || IsConnectionError(err)
+}
+
+// IsSerializationFailure returns whether the err indicates that a transaction could not be serialized with concurrent
+// transactions, such as a Postgres or SQL standard SQLSTATE 40001.
+func IsSerializationFailure(err error) bool {
+	state, ok := sqlState(err)
+	return ok && state == "40001"
+}
+
+// IsDeadlock returns whether the err indicates that a transaction was chosen as a deadlock victim or timed out waiting
+// for a lock, such as a Postgres SQLSTATE 40P01, a MySQL error 1213 or 1205, or a SQL Server error 1205.
+func IsDeadlock(err error) bool {
+	if state, ok := sqlState(err); ok && state == "40P01" {
+		return true
+	}
+	if number, ok := mysqlErrorNumber(err); ok && (number == 1213 || number == 1205) {
+		return true
+	}
+	if number, ok := sqlServerErrorNumber(err); ok && number == 1205 {
+		return true
+	}
+	return false
+}
+
+// IsConnectionError returns whether the err indicates that a connection to the database failed or was reset, such as
+// driver.ErrBadConn, a connection reset, refused, or broken pipe, a SQLSTATE in class 08, or a MySQL error 2006 or 2013.
+//
+// When a connection fails during a statement that is performed outside of a transaction, it may not be known whether the
+// statement was applied, so non-idempotent statements that are retried on connection errors should be performed via
+// DB.RunInTx.
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	if state, ok := sqlState(err); ok && strings.HasPrefix(state, "08") {
+		return true
+	}
+	if number, ok := mysqlErrorNumber(err); ok && (number == 2006 || number == 2013) {
+		return true
+	}
+	return false
+}
+
+// sqlState returns the SQLSTATE from an error that provides one, such as errors from the pgx and lib/pq drivers.
+func sqlState(err error) (string, bool) {
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		return stateErr.SQLState(), true
+	}
+	return "", false
+}
+
+// sqlServerErrorNumber returns the error number from a SQL Server error.
+func sqlServerErrorNumber(err error) (int32, bool) {
+	var numberErr interface{ SQLErrorNumber() int32 }
+	if errors.As(err, &numberErr) {
+		return numberErr.SQLErrorNumber(), true
+	}
+	return 0, false
+}
+
+// mysqlErrorNumber returns the number from a MySQL error in the err tree, such as an error that provides a Number
+// method. The MySQL driver's MySQLError only exposes its number as a field, so it's read from the field to avoid
+// importing the driver.
+func mysqlErrorNumber(err error) (uint64, bool) {
+	var numberErr interface{ Number() uint16 }
+	if errors.As(err, &numberErr) {
+		return uint64(numberErr.Number()), true
+	}
+	return mysqlErrorField(err)
+}
+
+// mysqlErrorField returns the Number field of the first MySQLError in the err tree, following both Unwrap() error and
+// Unwrap() []error, as errors.As does.
+func mysqlErrorField(err error) (uint64, bool) {
+	if err == nil {
+		return 0, false
+	}
+	if v := reflect.Indirect(reflect.ValueOf(err)); v.Kind() == reflect.Struct && v.Type().Name() == "MySQLError" {
+		if number := v.FieldByName("Number"); number.CanUint() {
+			return number.Uint(), true
+		}
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return mysqlErrorField(e.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if number, ok := mysqlErrorField(err); ok {
+				return number, true
+			}
+		}
+	}
+	return 0, false
+}
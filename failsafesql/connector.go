@@ -0,0 +1,40 @@
+package failsafesql
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// connector is a driver.Connector that establishes connections via an executor.
+type connector struct {
+	connector driver.Connector
+	executor  failsafe.Executor[driver.Conn]
+}
+
+// NewConnector returns a new driver.Connector that establishes connections via the inner connector and the policies,
+// such as a RetryPolicy or CircuitBreaker. The policies are composed around each connection attempt and will handle
+// connections in reverse order. The resulting connector can be used with sql.OpenDB.
+func NewConnector(inner driver.Connector, policies ...failsafe.Policy[driver.Conn]) driver.Connector {
+	return NewConnectorWithExecutor(inner, failsafe.NewExecutor(policies...))
+}
+
+// NewConnectorWithExecutor returns a new driver.Connector that establishes connections via the inner connector and the
+// executor.
+func NewConnectorWithExecutor(inner driver.Connector, executor failsafe.Executor[driver.Conn]) driver.Connector {
+	return &connector{
+		connector: inner,
+		executor:  executor,
+	}
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[driver.Conn]) (driver.Conn, error) {
+		return c.connector.Connect(exec.Context())
+	})
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
@@ -0,0 +1,94 @@
+package failsafesql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// DB wraps a *sql.DB so that queries, execs, and transactions are performed via an executor.
+//
+// Statements that are performed with a *sql.Tx are not handled by policies, since retrying a single statement inside of
+// a transaction is not safe after the transaction has been aborted by the database. Use RunInTx to retry a transaction
+// as a whole.
+//
+// Since the Rows from a query are closed when a query's context is canceled, a HedgePolicy should not be used with
+// QueryContext.
+type DB struct {
+	db       *sql.DB
+	executor failsafe.Executor[any]
+}
+
+// NewDB returns a new DB that performs queries, execs, and transactions against the db via the policies. The policies
+// are composed around each operation and will handle results in reverse order.
+func NewDB(db *sql.DB, policies ...failsafe.Policy[any]) *DB {
+	return NewDBWithExecutor(db, failsafe.NewExecutor(policies...))
+}
+
+// NewDBWithExecutor returns a new DB that performs queries, execs, and transactions against the db via the executor.
+func NewDBWithExecutor(db *sql.DB, executor failsafe.Executor[any]) *DB {
+	return &DB{
+		db:       db,
+		executor: executor,
+	}
+}
+
+// DB returns the underlying *sql.DB.
+func (d *DB) DB() *sql.DB {
+	return d.db
+}
+
+// QueryContext performs a query via the executor, returning the resulting Rows.
+func (d *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	result, err := d.executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		return d.db.QueryContext(exec.Context(), query, args...)
+	})
+	rows, _ := result.(*sql.Rows)
+	return rows, err
+}
+
+// ExecContext performs an exec via the executor, returning the resulting Result.
+func (d *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	result, err := d.executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		return d.db.ExecContext(exec.Context(), query, args...)
+	})
+	execResult, _ := result.(sql.Result)
+	return execResult, err
+}
+
+// BeginTx begins a transaction via the executor. Only beginning the transaction is handled by the policies, and not
+// the statements that are performed with the resulting Tx. See RunInTx.
+func (d *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	result, err := d.executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		return d.db.BeginTx(exec.Context(), opts)
+	})
+	tx, _ := result.(*sql.Tx)
+	return tx, err
+}
+
+// RunInTx performs the fn in a transaction via the executor, and commits the transaction if the fn succeeds. If the fn
+// or the commit fails, the transaction is rolled back. Policies handle the transaction as a whole, so a RetryPolicy will
+// retry failures by beginning a new transaction and performing the fn again. The fn should use the provided ctx and tx
+// for its statements, and should not have side effects outside of the transaction, since it may be performed more than
+// once.
+func (d *DB) RunInTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	return d.RunInTxWithOptions(ctx, nil, fn)
+}
+
+// RunInTxWithOptions performs the fn in a transaction with the opts via the executor. See RunInTx.
+func (d *DB) RunInTxWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	_, err := d.executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		tx, err := d.db.BeginTx(exec.Context(), opts)
+		if err != nil {
+			return nil, err
+		}
+		// Rollback is a no-op after a successful commit, and ensures the tx is released if the fn panics
+		defer tx.Rollback()
+		if err := fn(exec.Context(), tx); err != nil {
+			return nil, err
+		}
+		return nil, tx.Commit()
+	})
+	return err
+}
@@ -0,0 +1,290 @@
+package failsafesql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+var (
+	serializationFailure = &pgError{code: "40001"}
+	deadlock             = &pgError{code: "40P01"}
+	uniqueViolation      = &pgError{code: "23505"}
+)
+
+func TestQueryContextWithRetries(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(failTimes("SELECT 1", 2, serializationFailure))
+	db := NewDB(sqlDB, RetryPolicyBuilder[any]().Build())
+
+	// When
+	rows, err := db.QueryContext(context.Background(), "SELECT 1")
+
+	// Then
+	assert.NoError(t, err)
+	defer rows.Close()
+	var value int
+	assert.True(t, rows.Next())
+	assert.NoError(t, rows.Scan(&value))
+	assert.Equal(t, 1, value)
+	assert.Equal(t, []string{"SELECT 1", "SELECT 1", "SELECT 1"}, fake.Statements())
+}
+
+func TestExecContextWithRetries(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(failTimes("UPDATE a", 1, deadlock))
+	db := NewDB(sqlDB, RetryPolicyBuilder[any]().Build())
+
+	// When
+	result, err := db.ExecContext(context.Background(), "UPDATE a")
+
+	// Then
+	assert.NoError(t, err)
+	affected, _ := result.RowsAffected()
+	assert.Equal(t, int64(1), affected)
+	assert.Equal(t, []string{"UPDATE a", "UPDATE a"}, fake.Statements())
+}
+
+func TestExecContextShouldNotRetryNonTransientErrors(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(failTimes("INSERT a", 1, uniqueViolation))
+	db := NewDB(sqlDB, RetryPolicyBuilder[any]().Build())
+
+	// When
+	result, err := db.ExecContext(context.Background(), "INSERT a")
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, uniqueViolation)
+	assert.Equal(t, []string{"INSERT a"}, fake.Statements())
+}
+
+func TestExecContextWithOpenCircuitBreaker(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(nil)
+	cb := circuitbreaker.WithDefaults[any]()
+	cb.Open()
+	db := NewDB(sqlDB, cb)
+
+	// When
+	_, err := db.ExecContext(context.Background(), "INSERT a")
+
+	// Then
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Empty(t, fake.Statements())
+}
+
+// Tests that a transient failure of a statement inside a transaction retries the whole transaction, rather than the
+// statement.
+func TestRunInTxRetriesWholeTransaction(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(failTimes("UPDATE b", 1, serializationFailure))
+	db := NewDB(sqlDB, RetryPolicyBuilder[any]().Build())
+
+	// When
+	err := db.RunInTx(context.Background(), func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "INSERT a"); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "UPDATE b")
+		return err
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"BEGIN", "INSERT a", "UPDATE b", "ROLLBACK",
+		"BEGIN", "INSERT a", "UPDATE b", "COMMIT",
+	}, fake.Statements())
+}
+
+func TestRunInTxRetriesCommitFailures(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(failTimes("COMMIT", 1, serializationFailure))
+	db := NewDB(sqlDB, RetryPolicyBuilder[any]().Build())
+
+	// When
+	err := db.RunInTx(context.Background(), func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT a")
+		return err
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BEGIN", "INSERT a", "COMMIT", "BEGIN", "INSERT a", "COMMIT"}, fake.Statements())
+}
+
+func TestRunInTxShouldRollbackNonTransientErrors(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(nil)
+	db := NewDB(sqlDB, RetryPolicyBuilder[any]().Build())
+
+	// When
+	err := db.RunInTx(context.Background(), func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "INSERT a"); err != nil {
+			return err
+		}
+		return testutil.ErrInvalidState
+	})
+
+	// Then
+	assert.ErrorIs(t, err, testutil.ErrInvalidState)
+	assert.Equal(t, []string{"BEGIN", "INSERT a", "ROLLBACK"}, fake.Statements())
+}
+
+// Tests that statements performed with a Tx from BeginTx are not retried.
+func TestBeginTxShouldNotRetryStatements(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(failTimes("UPDATE a", 1, serializationFailure))
+	db := NewDB(sqlDB, RetryPolicyBuilder[any]().Build())
+
+	// When
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = tx.ExecContext(context.Background(), "UPDATE a")
+	assert.NoError(t, tx.Rollback())
+
+	// Then
+	assert.ErrorIs(t, err, serializationFailure)
+	assert.Equal(t, []string{"BEGIN", "UPDATE a", "ROLLBACK"}, fake.Statements())
+}
+
+func TestConnectorWithRetries(t *testing.T) {
+	// Given
+	fake := &fakeDriver{}
+	connErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	fake.connectErrs = []error{connErr, connErr}
+	sqlDB := sql.OpenDB(NewConnector(fake, RetryPolicyBuilder[driver.Conn]().Build()))
+	defer sqlDB.Close()
+
+	// When
+	err := sqlDB.PingContext(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fake.Connects())
+}
+
+func TestClassifiers(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		serialization bool
+		deadlock      bool
+		connection    bool
+	}{
+		{"postgres serialization failure", serializationFailure, true, false, false},
+		{"postgres deadlock", deadlock, false, true, false},
+		{"postgres connection failure", &pgError{code: "08006"}, false, false, true},
+		{"postgres unique violation", uniqueViolation, false, false, false},
+		{"mysql deadlock", &MySQLError{Number: 1213}, false, true, false},
+		{"mysql lock wait timeout", &MySQLError{Number: 1205}, false, true, false},
+		{"mysql server gone away", &MySQLError{Number: 2006}, false, false, true},
+		{"mysql duplicate entry", &MySQLError{Number: 1062}, false, false, false},
+		{"wrapped mysql deadlock", fmt.Errorf("update failed: %w", &MySQLError{Number: 1213}), false, true, false},
+		{"joined mysql deadlock", errors.Join(errors.New("rollback failed"), &MySQLError{Number: 1213}), false, true, false},
+		{"mysql number method", &mysqlNumberError{number: 2013}, false, false, true},
+		{"joined mysql number method", errors.Join(errors.New("rollback failed"), &mysqlNumberError{number: 1205}), false, true, false},
+		{"sql server deadlock", &sqlServerError{number: 1205}, false, true, false},
+		{"wrapped serialization failure", fmt.Errorf("update failed: %w", serializationFailure), true, false, false},
+		{"bad conn", driver.ErrBadConn, false, false, true},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, false, false, true},
+		{"other", errors.New("syntax error"), false, false, false},
+		{"nil", nil, false, false, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.serialization, IsSerializationFailure(tc.err))
+			assert.Equal(t, tc.deadlock, IsDeadlock(tc.err))
+			assert.Equal(t, tc.connection, IsConnectionError(tc.err))
+			assert.Equal(t, tc.serialization || tc.deadlock || tc.connection, IsTransient(tc.err))
+		})
+	}
+}
+
+// Tests that statements are retried when a driver returns a MySQL deadlock that's wrapped with other errors.
+func TestExecContextWithWrappedMySQLDeadlock(t *testing.T) {
+	// Given
+	mysqlDeadlock := fmt.Errorf("exec failed: %w", errors.Join(errors.New("rollback failed"), &MySQLError{Number: 1213}))
+	fake, sqlDB := newFakeDB(failTimes("UPDATE a", 1, mysqlDeadlock))
+	db := NewDB(sqlDB, RetryPolicyBuilder[any]().Build())
+
+	// When
+	_, err := db.ExecContext(context.Background(), "UPDATE a")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"UPDATE a", "UPDATE a"}, fake.Statements())
+}
+
+func TestRetryPolicyBuilderWithClassifiers(t *testing.T) {
+	// Given
+	fake, sqlDB := newFakeDB(failTimes("UPDATE a", 1, deadlock))
+	db := NewDB(sqlDB, RetryPolicyBuilder[any](IsSerializationFailure).Build())
+
+	// When
+	_, err := db.ExecContext(context.Background(), "UPDATE a")
+
+	// Then
+	assert.ErrorIs(t, err, deadlock)
+	assert.Equal(t, []string{"UPDATE a"}, fake.Statements())
+}
+
+// pgError is an error with a SQLSTATE, similar to errors from the pgx and lib/pq drivers.
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string {
+	return "SQLSTATE " + e.code
+}
+
+func (e *pgError) SQLState() string {
+	return e.code
+}
+
+// MySQLError mirrors the error type from the MySQL driver.
+type MySQLError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *MySQLError) Error() string {
+	return fmt.Sprintf("Error %d: %s", e.Number, e.Message)
+}
+
+// mysqlNumberError is an error that provides a MySQL error number via a method.
+type mysqlNumberError struct {
+	number uint16
+}
+
+func (e *mysqlNumberError) Error() string {
+	return fmt.Sprintf("Error %d", e.number)
+}
+
+func (e *mysqlNumberError) Number() uint16 {
+	return e.number
+}
+
+// sqlServerError is an error with a number, similar to errors from the SQL Server driver.
+type sqlServerError struct {
+	number int32
+}
+
+func (e *sqlServerError) Error() string {
+	return fmt.Sprintf("mssql: error %d", e.number)
+}
+
+func (e *sqlServerError) SQLErrorNumber() int32 {
+	return e.number
+}
@@ -0,0 +1,2 @@
+// Package failsafesql provides functions that can be used to integrate policies with database/sql.
+package failsafesql
@@ -0,0 +1,163 @@
+package failsafesql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// fakeDriver is a driver.Driver and driver.Connector whose connections perform statements via a handler. Statements,
+// including BEGIN, COMMIT, and ROLLBACK, are recorded in the order they're performed.
+type fakeDriver struct {
+	mtx         sync.Mutex
+	statements  []string
+	connects    int
+	connectErrs []error
+	// handler returns an error for a statement, or nil if it should succeed
+	handler func(statement string) error
+}
+
+var _ driver.Connector = &fakeDriver{}
+
+// newFakeDB returns a new fakeDriver along with a *sql.DB that uses it.
+func newFakeDB(handler func(statement string) error) (*fakeDriver, *sql.DB) {
+	d := &fakeDriver{handler: handler}
+	return d, sql.OpenDB(d)
+}
+
+// failTimes returns a handler that fails the statement with the err the first n times it's performed.
+func failTimes(statement string, n int, err error) func(string) error {
+	var mtx sync.Mutex
+	return func(s string) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if s == statement && n > 0 {
+			n--
+			return err
+		}
+		return nil
+	}
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return d.Connect(context.Background())
+}
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.connects++
+	if len(d.connectErrs) > 0 {
+		err := d.connectErrs[0]
+		d.connectErrs = d.connectErrs[1:]
+		return nil, err
+	}
+	return &fakeConn{driver: d}, nil
+}
+
+func (d *fakeDriver) Driver() driver.Driver {
+	return d
+}
+
+func (d *fakeDriver) perform(statement string) error {
+	d.mtx.Lock()
+	d.statements = append(d.statements, statement)
+	handler := d.handler
+	d.mtx.Unlock()
+	if handler != nil {
+		return handler(statement)
+	}
+	return nil
+}
+
+func (d *fakeDriver) Statements() []string {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return append([]string(nil), d.statements...)
+}
+
+func (d *fakeDriver) Connects() int {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.connects
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+var (
+	_ driver.ConnBeginTx    = &fakeConn{}
+	_ driver.ExecerContext  = &fakeConn{}
+	_ driver.QueryerContext = &fakeConn{}
+)
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	if err := c.driver.perform("BEGIN"); err != nil {
+		return nil, err
+	}
+	return &fakeTx{driver: c.driver}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := c.driver.perform(query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := c.driver.perform(query); err != nil {
+		return nil, err
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct {
+	driver *fakeDriver
+}
+
+func (t *fakeTx) Commit() error {
+	return t.driver.perform("COMMIT")
+}
+
+func (t *fakeTx) Rollback() error {
+	return t.driver.perform("ROLLBACK")
+}
+
+// fakeRows contains a single row with a single value of 1.
+type fakeRows struct {
+	done bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
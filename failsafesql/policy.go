@@ -0,0 +1,29 @@
+package failsafesql
+
+import (
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+// RetryPolicyBuilder returns a retrypolicy.RetryPolicyBuilder that will retry errors that are transient according to
+// any of the classifiers up to 2 times, by default. If no classifiers are provided, IsTransient is used. Additional
+// handling and delay configuration, such as a backoff to reduce contention between retried transactions, can be added to
+// the resulting builder.
+func RetryPolicyBuilder[R any](classifiers ...Classifier) retrypolicy.RetryPolicyBuilder[R] {
+	if len(classifiers) == 0 {
+		classifiers = []Classifier{IsTransient}
+	}
+	retryHandleFunc := func(_ R, err error) bool {
+		if err == nil {
+			return false
+		}
+		for _, classifier := range classifiers {
+			if classifier(err) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return retrypolicy.Builder[R]().
+		HandleIf(retryHandleFunc)
+}
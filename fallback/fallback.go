@@ -1,6 +1,8 @@
 package fallback
 
 import (
+	"log/slog"
+
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
@@ -30,6 +32,9 @@ type FallbackBuilder[R any] interface {
 	// the execution result and error returned by the Fallback.
 	OnFallbackExecuted(listener func(event failsafe.ExecutionDoneEvent[R])) FallbackBuilder[R]
 
+	// WithLogger configures the logger to log the Fallback's events to. See failsafe.Logger.
+	WithLogger(logger *slog.Logger) FallbackBuilder[R]
+
 	// Build returns a new Fallback using the builder's configuration.
 	Build() Fallback[R]
 }
@@ -38,6 +43,7 @@ type fallbackConfig[R any] struct {
 	*policy.BaseFailurePolicy[R]
 	fn                 func(failsafe.Execution[R]) (R, error)
 	onFallbackExecuted func(failsafe.ExecutionDoneEvent[R])
+	logger             *failsafe.Logger
 }
 
 var _ FallbackBuilder[any] = &fallbackConfig[any]{}
@@ -116,6 +122,11 @@ func (c *fallbackConfig[R]) OnFallbackExecuted(listener func(event failsafe.Exec
 	return c
 }
 
+func (c *fallbackConfig[R]) WithLogger(logger *slog.Logger) FallbackBuilder[R] {
+	c.logger = failsafe.NewLoggerBuilder(logger).Build()
+	return c
+}
+
 func (c *fallbackConfig[R]) Build() Fallback[R] {
 	fbCopy := *c
 	return &fallback[R]{
@@ -127,6 +138,7 @@ func (fb *fallback[R]) ToExecutor(_ R) any {
 	fbe := &fallbackExecutor[R]{
 		BaseExecutor: &policy.BaseExecutor[R]{
 			BaseFailurePolicy: fb.config.BaseFailurePolicy,
+			Logger:            fb.config.logger,
 		},
 		fallback: fb,
 	}
@@ -27,6 +27,7 @@ func (e *fallbackExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.
 				return cancelResult
 			}
 
+			e.RecordEvent(execInternal, failsafe.PolicyEvent{Type: failsafe.FallbackExecuted, Policy: "Fallback", Source: e.fallback, Error: result.Error})
 			if e.config.onFallbackExecuted != nil {
 				e.config.onFallbackExecuted(failsafe.ExecutionDoneEvent[R]{
 					ExecutionStats: execInternal,
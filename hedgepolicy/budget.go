@@ -0,0 +1,54 @@
+package hedgepolicy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/internal/util"
+)
+
+// The number of buckets that a hedge budget window is divided into.
+const budgetBucketCount = 10
+
+// Kinds of counts tracked by a hedge budget.
+const (
+	budgetExecutions = iota
+	budgetHedges
+)
+
+// hedgeBudget limits hedges to a percentage of the executions within a sliding time window. The window is divided into
+// buckets, each representing 1/10th of the window. As time progresses, counts for old buckets are discarded.
+//
+// This type is concurrency safe.
+type hedgeBudget struct {
+	percent uint
+	mtx     sync.Mutex
+
+	// Guarded by mtx
+	counts *util.RollingCounts
+}
+
+func newHedgeBudget(percent uint, window time.Duration, clock util.Clock) *hedgeBudget {
+	return &hedgeBudget{
+		percent: percent,
+		counts:  util.NewRollingCounts(2, window, budgetBucketCount, clock),
+	}
+}
+
+// recordExecution records an execution against the budget.
+func (b *hedgeBudget) recordExecution() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.counts.Add(budgetExecutions)
+}
+
+// tryAcquireHedge records a hedge and returns true if the hedge is within the budget, else returns false.
+func (b *hedgeBudget) tryAcquireHedge() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if (b.counts.Get(budgetHedges)+1)*100 > b.percent*b.counts.Get(budgetExecutions) {
+		return false
+	}
+	b.counts.Add(budgetHedges)
+	return true
+}
@@ -0,0 +1,62 @@
+package hedgepolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+func TestHedgeBudget(t *testing.T) {
+	clock := &testutil.TestClock{}
+	budget := newHedgeBudget(20, 10*time.Second, clock)
+
+	// No executions
+	assert.False(t, budget.tryAcquireHedge())
+
+	// 20% of 10 executions
+	recordBudgetExecutions(budget, 10)
+	assert.True(t, budget.tryAcquireHedge())
+	assert.True(t, budget.tryAcquireHedge())
+	assert.False(t, budget.tryAcquireHedge())
+
+	// 20% of 15 executions
+	clock.CurrentTime = testutil.MillisToNanos(5000)
+	recordBudgetExecutions(budget, 5)
+	assert.True(t, budget.tryAcquireHedge())
+	assert.False(t, budget.tryAcquireHedge())
+
+	// First 10 executions and 2 hedges expire, leaving 5 executions and 1 hedge
+	clock.CurrentTime = testutil.MillisToNanos(10500)
+	assert.False(t, budget.tryAcquireHedge())
+	recordBudgetExecutions(budget, 5)
+	assert.True(t, budget.tryAcquireHedge())
+	assert.False(t, budget.tryAcquireHedge())
+
+	// All executions and hedges expire
+	clock.CurrentTime = testutil.MillisToNanos(100000)
+	assert.False(t, budget.tryAcquireHedge())
+	recordBudgetExecutions(budget, 5)
+	assert.True(t, budget.tryAcquireHedge())
+}
+
+func recordBudgetExecutions(budget *hedgeBudget, executions int) {
+	for i := 0; i < executions; i++ {
+		budget.recordExecution()
+	}
+}
+
+// Asserts that a window smaller than the number of buckets still tracks executions and hedges.
+func TestHedgeBudgetWithSmallWindow(t *testing.T) {
+	clock := &testutil.TestClock{}
+	budget := newHedgeBudget(100, 5*time.Nanosecond, clock)
+
+	recordBudgetExecutions(budget, 1)
+	assert.True(t, budget.tryAcquireHedge())
+	assert.False(t, budget.tryAcquireHedge())
+
+	clock.CurrentTime = 100
+	assert.False(t, budget.tryAcquireHedge())
+}
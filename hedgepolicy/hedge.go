@@ -1,9 +1,13 @@
 package hedgepolicy
 
 import (
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/internal/util"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
@@ -20,6 +24,21 @@ import (
 // This type is concurrency safe.
 type HedgePolicy[R any] interface {
 	failsafe.Policy[R]
+
+	// Metrics returns metrics for the HedgePolicy.
+	Metrics() Metrics
+}
+
+// Metrics contains counters for a HedgePolicy, which are recorded across all executions of the policy.
+type Metrics interface {
+	// Executions returns the number of executions that have been performed with the HedgePolicy.
+	Executions() uint
+
+	// Hedges returns the number of hedges that have been performed.
+	Hedges() uint
+
+	// SkippedHedges returns the number of hedges that were skipped because they would have exceeded the hedge budget.
+	SkippedHedges() uint
 }
 
 // HedgePolicyBuilder builds HedgePolicy instances.
@@ -40,10 +59,37 @@ type HedgePolicyBuilder[R any] interface {
 	// OnHedge registers the listener to be called when a hedge is about to be attempted.
 	OnHedge(listener func(failsafe.ExecutionEvent[R])) HedgePolicyBuilder[R]
 
+	// OnDiscarded registers the listener to be called with every attempt result or error that is not returned from an
+	// execution, such as results from hedges that lost to another attempt, or that completed after the execution was
+	// canceled. This can be used to release resources held by discarded results, such as open connections or files.
+	OnDiscarded(listener func(R, error)) HedgePolicyBuilder[R]
+
+	// OnHedgeSkipped registers the listener to be called when a hedge is skipped because it would exceed the hedge budget.
+	OnHedgeSkipped(listener func(failsafe.ExecutionEvent[R])) HedgePolicyBuilder[R]
+
 	// WithMaxHedges sets the max number of hedges to perform when an execution attempt doesn't complete in time, which is 1
 	// by default.
 	WithMaxHedges(maxHedges int) HedgePolicyBuilder[R]
 
+	// HedgeOnFailure specifies that when an attempt fails with a result or error that does not cancel outstanding hedges,
+	// the next hedge should be started immediately rather than after the delay. This is similar to Happy Eyeballs in RFC
+	// 8305, where a failed connection attempt immediately starts an attempt against the next address.
+	HedgeOnFailure() HedgePolicyBuilder[R]
+
+	// WithBudget limits hedges to the percent, from 1 to 100, of executions within the rolling window. For example, a
+	// percent of 10 and a window of 1 second will allow up to 1 hedge for every 10 executions performed within the last
+	// second. Hedges that would exceed the budget are skipped and the execution waits for an outstanding attempt to
+	// complete instead. This prevents hedges from multiplying load when latency spikes across all executions.
+	//
+	// The window is divided into 10 time slices, each representing 1/10th of the window. As time progresses, counts for
+	// old time slices are discarded.
+	//
+	// Panics if the percent is not from 1 to 100, or if the window is not positive.
+	WithBudget(percent uint, window time.Duration) HedgePolicyBuilder[R]
+
+	// WithLogger configures the logger to log the HedgePolicy's events to. See failsafe.Logger.
+	WithLogger(logger *slog.Logger) HedgePolicyBuilder[R]
+
 	// Build returns a new HedgePolicy using the builder's configuration.
 	Build() HedgePolicy[R]
 }
@@ -51,9 +97,23 @@ type HedgePolicyBuilder[R any] interface {
 type hedgePolicyConfig[R any] struct {
 	*policy.BaseAbortablePolicy[R]
 
-	delayFunc failsafe.DelayFunc[R]
-	maxHedges int
-	onHedge   func(failsafe.ExecutionEvent[R])
+	clock          util.Clock
+	delayFunc      failsafe.DelayFunc[R]
+	maxHedges      int
+	hedgeOnFailure bool
+	onHedge        func(failsafe.ExecutionEvent[R])
+	onHedgeSkipped func(failsafe.ExecutionEvent[R])
+	onDiscarded    func(R, error)
+	logger         *failsafe.Logger
+
+	// Budget config
+	budgetPercent uint
+	budgetWindow  time.Duration
+
+	// Percentile delay config
+	percentile float64
+	minDelay   time.Duration
+	maxDelay   time.Duration
 }
 
 var _ HedgePolicyBuilder[any] = &hedgePolicyConfig[any]{}
@@ -99,13 +159,56 @@ func BuilderWithDelay[R any](delay time.Duration) HedgePolicyBuilder[R] {
 func BuilderWithDelayFunc[R any](delayFunc failsafe.DelayFunc[R]) HedgePolicyBuilder[R] {
 	return &hedgePolicyConfig[R]{
 		BaseAbortablePolicy: &policy.BaseAbortablePolicy[R]{},
+		clock:               util.NewClock(),
 		delayFunc:           delayFunc,
 		maxHedges:           1,
 	}
 }
 
+// WithPercentile returns a new HedgePolicy for execution result type R that performs a hedge once an execution attempt
+// takes longer than the percentile, from 0 to 100, of recent attempt latencies, clamped between the minDelay and maxDelay.
+// See BuilderWithPercentile for details.
+func WithPercentile[R any](percentile float64, minDelay time.Duration, maxDelay time.Duration) HedgePolicy[R] {
+	return BuilderWithPercentile[R](percentile, minDelay, maxDelay).Build()
+}
+
+// BuilderWithPercentile returns a new HedgePolicyBuilder for execution result type R that performs a hedge once an
+// execution attempt takes longer than the percentile, from 0 to 100, of recent attempt latencies. For example, a
+// percentile of 95 will hedge attempts that are slower than 95% of recent attempts. The resulting delay is clamped between
+// the minDelay and maxDelay. Until enough attempts have completed for a percentile to be computed, the maxDelay is used.
+//
+// Attempt latencies are tracked in a sliding window of the most recently completed attempts, which is shared by all
+// executions of the resulting HedgePolicy. Attempts that are canceled, such as attempts that lose to a hedge, are recorded
+// with the time until they were done, so that slow attempts are still reflected in the percentile.
+//
+// If the execution is configured with a Context, a child context will be created for the execution and canceled when the
+// HedgePolicy is exceeded.
+//
+// Panics if the percentile is not from 0 to 100, or if the minDelay is greater than the maxDelay.
+func BuilderWithPercentile[R any](percentile float64, minDelay time.Duration, maxDelay time.Duration) HedgePolicyBuilder[R] {
+	util.Assert(percentile >= 0 && percentile <= 100, "percentile must be from 0 to 100")
+	util.Assert(minDelay <= maxDelay, "minDelay must be <= maxDelay")
+	return &hedgePolicyConfig[R]{
+		BaseAbortablePolicy: &policy.BaseAbortablePolicy[R]{},
+		clock:               util.NewClock(),
+		maxHedges:           1,
+		percentile:          percentile,
+		minDelay:            minDelay,
+		maxDelay:            maxDelay,
+	}
+}
+
 type hedgePolicy[R any] struct {
 	config *hedgePolicyConfig[R]
+
+	// Recent attempt latencies, when a percentile delay is configured
+	latencies *latencyStats
+	// Hedge budget, when configured
+	budget *hedgeBudget
+
+	executions    atomic.Uint64
+	hedges        atomic.Uint64
+	skippedHedges atomic.Uint64
 }
 
 var _ HedgePolicy[any] = &hedgePolicy[any]{}
@@ -130,11 +233,39 @@ func (c *hedgePolicyConfig[R]) OnHedge(listener func(failsafe.ExecutionEvent[R])
 	return c
 }
 
+func (c *hedgePolicyConfig[R]) OnDiscarded(listener func(R, error)) HedgePolicyBuilder[R] {
+	c.onDiscarded = listener
+	return c
+}
+
+func (c *hedgePolicyConfig[R]) OnHedgeSkipped(listener func(failsafe.ExecutionEvent[R])) HedgePolicyBuilder[R] {
+	c.onHedgeSkipped = listener
+	return c
+}
+
 func (c *hedgePolicyConfig[R]) WithMaxHedges(maxHedges int) HedgePolicyBuilder[R] {
 	c.maxHedges = maxHedges
 	return c
 }
 
+func (c *hedgePolicyConfig[R]) HedgeOnFailure() HedgePolicyBuilder[R] {
+	c.hedgeOnFailure = true
+	return c
+}
+
+func (c *hedgePolicyConfig[R]) WithBudget(percent uint, window time.Duration) HedgePolicyBuilder[R] {
+	util.Assert(percent >= 1 && percent <= 100, "percent must be from 1 to 100")
+	util.Assert(window > 0, "window must be positive")
+	c.budgetPercent = percent
+	c.budgetWindow = window
+	return c
+}
+
+func (c *hedgePolicyConfig[R]) WithLogger(logger *slog.Logger) HedgePolicyBuilder[R] {
+	c.logger = failsafe.NewLoggerBuilder(logger).Build()
+	return c
+}
+
 func (c *hedgePolicyConfig[R]) Build() HedgePolicy[R] {
 	hCopy := *c
 	if !c.BaseAbortablePolicy.IsConfigured() {
@@ -143,15 +274,76 @@ func (c *hedgePolicyConfig[R]) Build() HedgePolicy[R] {
 			return true
 		})
 	}
-	return &hedgePolicy[R]{
+	hp := &hedgePolicy[R]{
 		config: &hCopy, // TODO copy base fields
 	}
+	if hCopy.delayFunc == nil {
+		hp.latencies = newLatencyStats(defaultLatencyWindowSize)
+	}
+	if hCopy.budgetWindow != 0 {
+		hp.budget = newHedgeBudget(hCopy.budgetPercent, hCopy.budgetWindow, hCopy.clock)
+	}
+	return hp
+}
+
+func (h *hedgePolicy[R]) Metrics() Metrics {
+	return h
+}
+
+func (h *hedgePolicy[R]) Executions() uint {
+	return uint(h.executions.Load())
+}
+
+func (h *hedgePolicy[R]) Hedges() uint {
+	return uint(h.hedges.Load())
+}
+
+func (h *hedgePolicy[R]) SkippedHedges() uint {
+	return uint(h.skippedHedges.Load())
+}
+
+// recordExecution records the start of an execution.
+func (h *hedgePolicy[R]) recordExecution() {
+	h.executions.Add(1)
+	if h.budget != nil {
+		h.budget.recordExecution()
+	}
+}
+
+// discard calls the onDiscarded listener, if any, for a result that will not be returned.
+func (h *hedgePolicy[R]) discard(result *common.PolicyResult[R]) {
+	if h.config.onDiscarded != nil {
+		h.config.onDiscarded(result.Result, result.Error)
+	}
+}
+
+// tryAcquireHedge returns whether a hedge can be performed, recording it if so.
+func (h *hedgePolicy[R]) tryAcquireHedge() bool {
+	if h.budget != nil && !h.budget.tryAcquireHedge() {
+		h.skippedHedges.Add(1)
+		return false
+	}
+	h.hedges.Add(1)
+	return true
+}
+
+// computeDelay returns the delay to wait before performing a hedge.
+func (h *hedgePolicy[R]) computeDelay(exec failsafe.ExecutionAttempt[R]) time.Duration {
+	if h.latencies == nil {
+		return h.config.delayFunc(exec)
+	}
+	if delay, ok := h.latencies.percentile(h.config.percentile); ok {
+		return min(max(delay, h.config.minDelay), h.config.maxDelay)
+	}
+	return h.config.maxDelay
 }
 
 func (h *hedgePolicy[R]) ToExecutor(_ R) any {
 	he := &hedgeExecutor[R]{
-		BaseExecutor: &policy.BaseExecutor[R]{},
-		hedgePolicy:  h,
+		BaseExecutor: &policy.BaseExecutor[R]{
+			Logger: h.config.logger,
+		},
+		hedgePolicy: h,
 	}
 	he.Executor = he
 	return he
@@ -0,0 +1,65 @@
+package hedgepolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+func TestBuilderWithPercentileShouldValidate(t *testing.T) {
+	assert.Panics(t, func() {
+		BuilderWithPercentile[any](-1, 0, time.Second)
+	})
+	assert.Panics(t, func() {
+		BuilderWithPercentile[any](101, 0, time.Second)
+	})
+	assert.Panics(t, func() {
+		BuilderWithPercentile[any](90, time.Second, time.Millisecond)
+	})
+}
+
+func TestWithBudgetShouldValidate(t *testing.T) {
+	assert.Panics(t, func() {
+		BuilderWithDelay[any](time.Second).WithBudget(0, time.Second)
+	})
+	assert.Panics(t, func() {
+		BuilderWithDelay[any](time.Second).WithBudget(101, time.Second)
+	})
+	assert.Panics(t, func() {
+		BuilderWithDelay[any](time.Second).WithBudget(10, 0)
+	})
+	assert.NotPanics(t, func() {
+		BuilderWithDelay[any](time.Second).WithBudget(100, time.Nanosecond)
+	})
+}
+
+// Asserts that the latencies of attempts that lose to a hedge are recorded, so that slow attempts are reflected in the
+// percentile.
+func TestPercentileShouldRecordCanceledAttempts(t *testing.T) {
+	// Given
+	hp := WithPercentile[bool](50, 0, 10*time.Millisecond).(*hedgePolicy[bool])
+
+	// When
+	for i := 0; i < minLatencySamples/2; i++ {
+		_, err := failsafe.NewExecutor[bool](hp).GetWithExecution(func(exec failsafe.Execution[bool]) (bool, error) {
+			if !exec.IsHedge() {
+				<-exec.Canceled()
+			}
+			return true, nil
+		})
+		assert.NoError(t, err)
+	}
+
+	// Then
+	assert.Eventually(t, func() bool {
+		hp.latencies.mtx.Lock()
+		defer hp.latencies.mtx.Unlock()
+		return hp.latencies.size == minLatencySamples
+	}, time.Second, 10*time.Millisecond)
+	p50, ok := hp.latencies.percentile(50)
+	assert.True(t, ok)
+	assert.Greater(t, p50, time.Duration(0))
+}
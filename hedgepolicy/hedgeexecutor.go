@@ -1,7 +1,7 @@
 package hedgepolicy
 
 import (
-	"sync/atomic"
+	"sync"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
@@ -20,59 +20,156 @@ var _ policy.Executor[any] = &hedgeExecutor[any]{}
 func (e *hedgeExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
 	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
 		execInternal := exec.(policy.ExecutionInternal[R])
+		e.recordExecution()
 
 		// Create a cancellable parent execution for all attempts
 		parentExecution := execInternal.CopyForCancellable().(policy.ExecutionInternal[R])
 		execInternal = parentExecution
-
-		// Guard against a race between execution results
-		done := atomic.Bool{}
-		resultCount := atomic.Int32{}
-		resultChan := make(chan *common.PolicyResult[R], 1) // Only the first result is sent
+		results := &hedgeResults[R]{
+			executor:        e,
+			parentExecution: parentExecution,
+			maxResults:      e.config.maxHedges + 1,
+			resultChan:      make(chan *common.PolicyResult[R], 1), // Only the first result is sent
+		}
+		if e.config.hedgeOnFailure {
+			results.failureChan = make(chan struct{}, 1)
+		}
 
 		for attempts := 1; ; attempts++ {
 			go func(hedgeExec policy.ExecutionInternal[R]) {
+				attemptStartTime := time.Now()
 				result := innerFn(hedgeExec)
-				isFinalResult := int(resultCount.Add(1)) == e.config.maxHedges+1
-				isCancellable := e.config.IsAbortable(result.Result, result.Error)
-
-				if (isFinalResult || isCancellable) && done.CompareAndSwap(false, true) {
-					// Cancel any outstanding attempts without recording a result
-					if cancelResult := parentExecution.Cancel(nil); cancelResult != nil {
-						result = cancelResult
-					}
-					resultChan <- result
+				if e.latencies != nil {
+					e.latencies.record(time.Since(attemptStartTime))
 				}
+				results.record(result)
 			}(execInternal)
 
 			if attempts-1 < e.config.maxHedges {
 				// Wait for hedge delay or result
-				timer := time.NewTimer(e.config.delayFunc(exec))
+				timer := time.NewTimer(e.computeDelay(exec))
 				select {
 				case <-timer.C:
-				case result := <-resultChan:
+				case <-results.failureChan:
+					timer.Stop()
+				case result := <-results.resultChan:
 					timer.Stop()
 					return result
 				}
 			} else {
 				// All hedges have been started, wait for a result
-				select {
-				case result := <-resultChan:
-					return result
-				}
+				return <-results.resultChan
 			}
 
 			if canceled, cancelResult := execInternal.IsCanceledWithResult(); canceled {
+				results.discardAll()
 				return cancelResult
 			}
 
+			if !e.tryAcquireHedge() {
+				e.RecordEvent(parentExecution, failsafe.PolicyEvent{Type: failsafe.HedgeSkipped, Policy: "HedgePolicy", Source: e.hedgePolicy})
+				if e.config.onHedgeSkipped != nil {
+					e.config.onHedgeSkipped(failsafe.ExecutionEvent[R]{ExecutionAttempt: parentExecution.CopyWithResult(nil)})
+				}
+
+				// No more hedges will be started, so the result from the last outstanding attempt is final
+				results.limit(attempts)
+				return <-results.resultChan
+			}
+
 			// Prepare for hedge execution
 			execInternal = parentExecution.CopyForHedge().(policy.ExecutionInternal[R])
 
 			// Call hedge listener
+			e.RecordEvent(execInternal, failsafe.PolicyEvent{Type: failsafe.HedgeStarted, Policy: "HedgePolicy", Source: e.hedgePolicy})
 			if e.config.onHedge != nil {
 				e.config.onHedge(failsafe.ExecutionEvent[R]{ExecutionAttempt: execInternal.CopyWithResult(nil)})
 			}
 		}
 	}
 }
+
+// hedgeResults collects results for the attempts of a hedged execution, sends the final result to the resultChan, and
+// discards any other results.
+type hedgeResults[R any] struct {
+	executor        *hedgeExecutor[R]
+	parentExecution policy.ExecutionInternal[R]
+	resultChan      chan *common.PolicyResult[R]
+	failureChan     chan struct{} // Signaled when an attempt fails, if hedging on failures
+	mtx             sync.Mutex
+
+	// Guarded by mtx
+	done        bool
+	resultCount int
+	maxResults  int
+	pending     []*common.PolicyResult[R] // Results that were received before a final result
+}
+
+// record records an attempt's result, sending it if it's final, else discarding it if a final result was already sent.
+func (r *hedgeResults[R]) record(result *common.PolicyResult[R]) {
+	r.mtx.Lock()
+	r.resultCount++
+	if r.done {
+		r.mtx.Unlock()
+		r.executor.discard(result)
+		return
+	}
+	isFinalResult := r.resultCount == r.maxResults
+	isCancellable := r.executor.config.IsAbortable(result.Result, result.Error)
+	if !isFinalResult && !isCancellable {
+		r.pending = append(r.pending, result)
+		r.mtx.Unlock()
+		select {
+		case r.failureChan <- struct{}{}:
+		default:
+		}
+		return
+	}
+	discards := r.markDone()
+	r.mtx.Unlock()
+	r.send(result, discards)
+}
+
+// limit limits the results to the number of attempts that were started, sending the last pending result if all results
+// were already received.
+func (r *hedgeResults[R]) limit(attempts int) {
+	r.mtx.Lock()
+	r.maxResults = attempts
+	if r.done || r.resultCount < attempts {
+		r.mtx.Unlock()
+		return
+	}
+	discards := r.markDone()
+	r.mtx.Unlock()
+	r.send(discards[len(discards)-1], discards[:len(discards)-1])
+}
+
+// discardAll discards any pending and subsequent results.
+func (r *hedgeResults[R]) discardAll() {
+	r.mtx.Lock()
+	discards := r.markDone()
+	r.mtx.Unlock()
+	for _, discard := range discards {
+		r.executor.discard(discard)
+	}
+}
+
+// markDone marks the results as done and returns any pending results. Must be locked externally.
+func (r *hedgeResults[R]) markDone() []*common.PolicyResult[R] {
+	r.done = true
+	pending := r.pending
+	r.pending = nil
+	return pending
+}
+
+func (r *hedgeResults[R]) send(result *common.PolicyResult[R], discards []*common.PolicyResult[R]) {
+	// Discard any outstanding attempts without recording a result
+	if cancelResult := r.parentExecution.Discard(); cancelResult != nil {
+		discards = append(discards, result)
+		result = cancelResult
+	}
+	r.resultChan <- result
+	for _, discard := range discards {
+		r.executor.discard(discard)
+	}
+}
@@ -0,0 +1,91 @@
+package hedgepolicy
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// The default number of recent attempt latencies that percentiles are computed from.
+	defaultLatencyWindowSize = 1000
+
+	// The min number of latencies that must be recorded before a percentile is computed.
+	minLatencySamples = 10
+
+	// Latencies are recorded into exponentially sized buckets, starting at minBucketLatency and growing by bucketGrowthFactor,
+	// which bounds the error of a computed percentile to the growth factor.
+	minBucketLatency   = time.Microsecond
+	bucketGrowthFactor = 1.1
+	bucketCount        = 256
+)
+
+var logBucketGrowthFactor = math.Log(bucketGrowthFactor)
+
+// latencyStats is a sliding histogram of the most recent attempt latencies, used to compute percentile based hedge delays.
+//
+// This type is concurrency safe.
+type latencyStats struct {
+	mtx sync.Mutex
+
+	// Guarded by mtx
+	buckets      [bucketCount]uint
+	window       []uint8 // The bucket index of each recorded latency, in the order they were recorded
+	currentIndex int     // Index to write the next entry to
+	size         int     // The number of occupied entries in the window
+}
+
+func newLatencyStats(windowSize int) *latencyStats {
+	return &latencyStats{
+		window: make([]uint8, windowSize),
+	}
+}
+
+// record records the latency, evicting the oldest recorded latency if the window is full.
+func (s *latencyStats) record(latency time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.size < len(s.window) {
+		s.size++
+	} else {
+		s.buckets[s.window[s.currentIndex]]--
+	}
+	index := bucketIndexFor(latency)
+	s.buckets[index]++
+	s.window[s.currentIndex] = index
+	s.currentIndex = (s.currentIndex + 1) % len(s.window)
+}
+
+// percentile returns the latency at the percentile, from 0 to 100, along with whether enough latencies have been recorded
+// to compute it.
+func (s *latencyStats) percentile(percentile float64) (time.Duration, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.size < minLatencySamples {
+		return 0, false
+	}
+	target := uint(math.Ceil(percentile / 100 * float64(s.size)))
+	var count uint
+	for i, bucketSize := range s.buckets {
+		count += bucketSize
+		if count >= max(target, 1) {
+			return bucketUpperBound(i), true
+		}
+	}
+	return bucketUpperBound(bucketCount - 1), true
+}
+
+// bucketIndexFor returns the index of the smallest bucket whose upper bound is >= the latency.
+func bucketIndexFor(latency time.Duration) uint8 {
+	if latency <= minBucketLatency {
+		return 0
+	}
+	index := int(math.Ceil(math.Log(float64(latency)/float64(minBucketLatency)) / logBucketGrowthFactor))
+	return uint8(min(index, bucketCount-1))
+}
+
+func bucketUpperBound(index int) time.Duration {
+	return time.Duration(float64(minBucketLatency) * math.Pow(bucketGrowthFactor, float64(index)))
+}
@@ -0,0 +1,71 @@
+package hedgepolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyStatsShouldRequireMinSamples(t *testing.T) {
+	stats := newLatencyStats(100)
+	recordLatencies(stats, minLatencySamples-1, time.Millisecond)
+
+	_, ok := stats.percentile(50)
+	assert.False(t, ok)
+
+	recordLatencies(stats, 1, time.Millisecond)
+	_, ok = stats.percentile(50)
+	assert.True(t, ok)
+}
+
+func TestLatencyStatsPercentile(t *testing.T) {
+	stats := newLatencyStats(100)
+	for i := 1; i <= 100; i++ {
+		stats.record(time.Duration(i) * time.Millisecond)
+	}
+
+	assertPercentile(t, stats, 50, 50*time.Millisecond)
+	assertPercentile(t, stats, 90, 90*time.Millisecond)
+	assertPercentile(t, stats, 99, 99*time.Millisecond)
+	assertPercentile(t, stats, 100, 100*time.Millisecond)
+}
+
+// Asserts that old latencies are evicted from the window as new latencies are recorded.
+func TestLatencyStatsShouldSlide(t *testing.T) {
+	stats := newLatencyStats(20)
+	recordLatencies(stats, 20, time.Second)
+	assertPercentile(t, stats, 50, time.Second)
+
+	recordLatencies(stats, 15, 10*time.Millisecond)
+	assertPercentile(t, stats, 50, 10*time.Millisecond)
+	assertPercentile(t, stats, 90, time.Second)
+
+	recordLatencies(stats, 5, 10*time.Millisecond)
+	assertPercentile(t, stats, 100, 10*time.Millisecond)
+}
+
+func TestBucketIndexFor(t *testing.T) {
+	assert.Equal(t, uint8(0), bucketIndexFor(0))
+	assert.Equal(t, uint8(0), bucketIndexFor(minBucketLatency))
+	assert.Equal(t, uint8(bucketCount-1), bucketIndexFor(100*time.Hour))
+	for _, latency := range []time.Duration{2 * time.Microsecond, time.Millisecond, 3 * time.Second} {
+		index := bucketIndexFor(latency)
+		assert.GreaterOrEqual(t, bucketUpperBound(int(index)), latency)
+		assert.Less(t, bucketUpperBound(int(index)-1), latency)
+	}
+}
+
+func recordLatencies(stats *latencyStats, count int, latency time.Duration) {
+	for i := 0; i < count; i++ {
+		stats.record(latency)
+	}
+}
+
+// Asserts that the percentile is within the precision of the latency buckets.
+func assertPercentile(t *testing.T, stats *latencyStats, percentile float64, expected time.Duration) {
+	actual, ok := stats.percentile(percentile)
+	assert.True(t, ok)
+	assert.GreaterOrEqual(t, actual, expected)
+	assert.LessOrEqual(t, float64(actual), float64(expected)*bucketGrowthFactor)
+}
@@ -115,6 +115,9 @@ func WithHedgeStatsAndLogs[R any](hp hedgepolicy.HedgePolicyBuilder[R], stats *S
 	hp.OnHedge(func(e failsafe.ExecutionEvent[R]) {
 		stats.hedges.Add(1)
 		fmt.Printf("%s %p hedging [attempts: %v]\n", testutil.GetType(hp), hp, e.Attempts())
+	}).OnHedgeSkipped(func(e failsafe.ExecutionEvent[R]) {
+		stats.hedgesSkipped.Add(1)
+		fmt.Printf("%s %p hedge skipped [attempts: %v]\n", testutil.GetType(hp), hp, e.Attempts())
 	})
 	return hp
 }
@@ -170,7 +173,8 @@ type Stats struct {
 	aborts          atomic.Int32
 
 	// Hedge specific stats
-	hedges atomic.Int32
+	hedges        atomic.Int32
+	hedgesSkipped atomic.Int32
 
 	// Bulkhead specific stats
 	fulls atomic.Int32
@@ -206,6 +210,10 @@ func (s *Stats) Hedges() int {
 	return int(s.hedges.Load())
 }
 
+func (s *Stats) HedgesSkipped() int {
+	return int(s.hedgesSkipped.Load())
+}
+
 func (s *Stats) Aborts() int {
 	return int(s.aborts.Load())
 }
@@ -238,6 +246,7 @@ func (s *Stats) Reset() {
 
 	// Hedge specific stats
 	s.hedges.Store(0)
+	s.hedgesSkipped.Store(0)
 
 	// Bulkhead specific stats
 	s.fulls.Store(0)
@@ -0,0 +1,60 @@
+package rejection
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+)
+
+// Observer is a failsafe.Observer that records how long a client should wait before retrying an execution that was
+// rejected by a RateLimiter or an open CircuitBreaker. Only the policies that rejected the execution are considered, and
+// a RateLimiter's wait time is determined via its Metrics, without reserving a permit.
+type Observer[R any] struct {
+	mtx        sync.Mutex
+	retryDelay time.Duration
+}
+
+var _ failsafe.Observer = &Observer[any]{}
+
+// RetryDelay returns the longest delay required by a RateLimiter or CircuitBreaker that rejected the execution, else 0.
+func (o *Observer[R]) RetryDelay() time.Duration {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return o.retryDelay
+}
+
+func (o *Observer[R]) PolicyEvent(_ context.Context, event failsafe.PolicyEvent) {
+	var delay time.Duration
+	switch source := event.Source.(type) {
+	case ratelimiter.RateLimiter[R]:
+		if event.Type == failsafe.RateLimitExceeded {
+			delay = source.Metrics().WaitTime()
+		}
+	case circuitbreaker.CircuitBreaker[R]:
+		if event.Type == failsafe.CircuitBreakerRejected {
+			delay = source.RemainingDelay()
+		}
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.retryDelay = max(o.retryDelay, delay)
+}
+
+func (o *Observer[R]) ExecutionStarted(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (o *Observer[R]) AttemptStarted(ctx context.Context, _ failsafe.AttemptStats) context.Context {
+	return ctx
+}
+
+func (o *Observer[R]) AttemptDone(context.Context, failsafe.AttemptStats, error) {
+}
+
+func (o *Observer[R]) ExecutionDone(context.Context, failsafe.ExecutionStats, error) {
+}
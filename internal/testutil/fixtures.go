@@ -153,6 +153,10 @@ type TestExecution[R any] struct {
 	TheHedges     int
 }
 
+func (e TestExecution[R]) ID() string {
+	panic("unimplemented stub")
+}
+
 func (e TestExecution[R]) Attempts() int {
 	return e.TheAttempts
 }
@@ -205,6 +209,10 @@ func (e TestExecution[R]) ElapsedAttemptTime() time.Duration {
 	panic("unimplemented stub")
 }
 
+func (e TestExecution[R]) Target() string {
+	panic("unimplemented stub")
+}
+
 func (e TestExecution[R]) Context() context.Context {
 	return nil
 }
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"time"
+)
+
+// RollingCounts tracks counts of different kinds within a sliding time window. The window is divided into buckets, and as
+// time progresses, counts for old buckets are discarded.
+//
+// This type is not concurrency safe.
+type RollingCounts struct {
+	clock      Clock
+	bucketSize int64
+
+	buckets      []rollingBucket
+	summary      []uint
+	currentIndex int
+}
+
+type rollingBucket struct {
+	counts    []uint
+	startTime int64
+}
+
+// NewRollingCounts returns a new RollingCounts that tracks counts for the number of kinds within the window, which is
+// divided into the bucketCount. Buckets are at least 1ns long, regardless of the window.
+func NewRollingCounts(kinds int, window time.Duration, bucketCount int, clock Clock) *RollingCounts {
+	buckets := make([]rollingBucket, bucketCount)
+	for i := range buckets {
+		buckets[i].counts = make([]uint, kinds)
+	}
+	c := &RollingCounts{
+		clock:      clock,
+		bucketSize: max(window.Nanoseconds()/int64(bucketCount), 1),
+		buckets:    buckets,
+		summary:    make([]uint, kinds),
+	}
+	c.Reset()
+	return c
+}
+
+// Add increments the count for the kind.
+func (c *RollingCounts) Add(kind int) {
+	c.currentBucket().counts[kind]++
+	c.summary[kind]++
+}
+
+// Get returns the count for the kind within the window.
+func (c *RollingCounts) Get(kind int) uint {
+	c.currentBucket()
+	return c.summary[kind]
+}
+
+// Reset discards all counts.
+func (c *RollingCounts) Reset() {
+	startTime := c.clock.CurrentUnixNano()
+	for i := range c.buckets {
+		clear(c.buckets[i].counts)
+		c.buckets[i].startTime = startTime
+		startTime += c.bucketSize
+	}
+	clear(c.summary)
+	c.currentIndex = 0
+}
+
+func (c *RollingCounts) currentBucket() *rollingBucket {
+	bucket := &c.buckets[c.currentIndex]
+	timeDiff := c.clock.CurrentUnixNano() - bucket.startTime
+	if timeDiff < c.bucketSize {
+		return bucket
+	}
+
+	bucketsToMove := timeDiff / c.bucketSize
+	if bucketsToMove > int64(len(c.buckets)) {
+		c.Reset()
+		return &c.buckets[0]
+	}
+	for ; bucketsToMove > 0; bucketsToMove-- {
+		startTime := bucket.startTime + c.bucketSize
+		c.currentIndex = (c.currentIndex + 1) % len(c.buckets)
+		bucket = &c.buckets[c.currentIndex]
+		for kind, count := range bucket.counts {
+			c.summary[kind] -= count
+		}
+		clear(bucket.counts)
+		bucket.startTime = startTime
+	}
+	return bucket
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct {
+	currentTime int64
+}
+
+func (c *testClock) CurrentUnixNano() int64 {
+	return c.currentTime
+}
+
+func TestRollingCounts(t *testing.T) {
+	clock := &testClock{}
+	counts := NewRollingCounts(2, 10*time.Second, 10, clock)
+
+	counts.Add(0)
+	counts.Add(0)
+	counts.Add(1)
+	assert.Equal(t, uint(2), counts.Get(0))
+	assert.Equal(t, uint(1), counts.Get(1))
+
+	// Add counts to a later bucket
+	clock.currentTime = (5 * time.Second).Nanoseconds()
+	counts.Add(0)
+	assert.Equal(t, uint(3), counts.Get(0))
+
+	// First bucket expires
+	clock.currentTime = (10500 * time.Millisecond).Nanoseconds()
+	assert.Equal(t, uint(1), counts.Get(0))
+	assert.Equal(t, uint(0), counts.Get(1))
+
+	// All buckets expire
+	clock.currentTime = (100 * time.Second).Nanoseconds()
+	assert.Equal(t, uint(0), counts.Get(0))
+}
+
+// Asserts that a window smaller than the bucket count does not result in empty buckets.
+func TestRollingCountsWithSmallWindow(t *testing.T) {
+	clock := &testClock{}
+	counts := NewRollingCounts(1, 5*time.Nanosecond, 10, clock)
+
+	counts.Add(0)
+	assert.Equal(t, uint(1), counts.Get(0))
+	clock.currentTime = 9
+	assert.Equal(t, uint(1), counts.Get(0))
+	clock.currentTime = 10
+	assert.Equal(t, uint(0), counts.Get(0))
+}
@@ -8,6 +8,13 @@ type number interface {
 	~int | ~int64 | ~uint | ~uint64
 }
 
+// Assert panics with the message if the condition is false, which is used to validate builder arguments.
+func Assert(condition bool, message string) {
+	if !condition {
+		panic(message)
+	}
+}
+
 // AppliesToAny returns true if any of the biPredicates evaluate to true for the values.
 func AppliesToAny[A any, B any](biPredicates []func(A, B) bool, value1 A, value2 B) bool {
 	for _, p := range biPredicates {
@@ -0,0 +1,2 @@
+// Package loadshed provides a LoadShedder policy.
+package loadshed
@@ -0,0 +1,208 @@
+package loadshed
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// ErrShed is returned when an execution is rejected by a LoadShedder.
+var ErrShed = errors.New("load shed")
+
+type key int
+
+// PriorityKey is a key to use with a Context that stores the Priority of an execution.
+const PriorityKey key = 0
+
+// Priority is the priority class of an execution. Executions with lower priorities are shed first.
+type Priority int
+
+func (p Priority) String() string {
+	switch p {
+	case LowPriority:
+		return "low"
+	case MediumPriority:
+		return "medium"
+	case HighPriority:
+		return "high"
+	case CriticalPriority:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// LowPriority executions are shed first, when the load reaches .8 by default.
+	LowPriority Priority = iota
+
+	// MediumPriority executions are shed when the load reaches .9 by default.
+	MediumPriority
+
+	// HighPriority executions are shed when the load reaches 1 by default.
+	HighPriority
+
+	// CriticalPriority executions are never shed by default.
+	CriticalPriority
+)
+
+/*
+LoadShedder is a server side policy that rejects executions with ErrShed when a system is overloaded, according to a
+Signal. Executions carry a Priority, via a PriorityKey in their Context, and each Priority is shed when the load reported
+by the Signal reaches a threshold for that Priority, so that lower priority executions are shed first.
+
+A LoadShedder can be composed outside of a Bulkhead, in which case a CoDelSignal will include the time that executions
+wait for a bulkhead permit.
+
+This type is concurrency safe.
+*/
+type LoadShedder[R any] interface {
+	failsafe.Policy[R]
+
+	// TryAcquirePermit tries to acquire a permit to perform an execution with the priority, returning whether the permit
+	// was acquired. Callers should call ReleasePermit with the execution's duration to release a successfully acquired
+	// permit.
+	TryAcquirePermit(priority Priority) bool
+
+	// ReleasePermit releases a permit for an execution that took the duration.
+	ReleasePermit(duration time.Duration)
+
+	// Load returns the current load reported by the Signal.
+	Load() float64
+}
+
+// ShedEvent indicates an execution was shed.
+type ShedEvent[R any] struct {
+	failsafe.ExecutionEvent[R]
+
+	// Priority is the priority of the execution that was shed.
+	Priority Priority
+
+	// Load is the load that caused the execution to be shed.
+	Load float64
+}
+
+// LoadShedderBuilder builds LoadShedder instances.
+//
+// This type is not concurrency safe.
+type LoadShedderBuilder[R any] interface {
+	// WithThreshold configures the load at which executions with the priority are shed. A threshold of math.Inf(1)
+	// indicates executions with the priority are never shed.
+	WithThreshold(priority Priority, load float64) LoadShedderBuilder[R]
+
+	// WithDefaultPriority configures the priority for executions whose Context does not contain a PriorityKey. Defaults to
+	// MediumPriority.
+	WithDefaultPriority(priority Priority) LoadShedderBuilder[R]
+
+	// OnShed registers the listener to be called when an execution is shed.
+	OnShed(listener func(event ShedEvent[R])) LoadShedderBuilder[R]
+
+	// Build returns a new LoadShedder using the builder's configuration.
+	Build() LoadShedder[R]
+}
+
+type loadShedderConfig[R any] struct {
+	signal          Signal
+	thresholds      map[Priority]float64
+	defaultPriority Priority
+	onShed          func(ShedEvent[R])
+}
+
+var _ LoadShedderBuilder[any] = &loadShedderConfig[any]{}
+
+// With returns a new LoadShedder for execution result type R that sheds executions according to the signal.
+func With[R any](signal Signal) LoadShedder[R] {
+	return Builder[R](signal).Build()
+}
+
+// Builder returns a LoadShedderBuilder for execution result type R that builds LoadShedders that shed executions
+// according to the signal.
+func Builder[R any](signal Signal) LoadShedderBuilder[R] {
+	return &loadShedderConfig[R]{
+		signal: signal,
+		thresholds: map[Priority]float64{
+			LowPriority:      .8,
+			MediumPriority:   .9,
+			HighPriority:     1,
+			CriticalPriority: math.Inf(1),
+		},
+		defaultPriority: MediumPriority,
+	}
+}
+
+func (c *loadShedderConfig[R]) WithThreshold(priority Priority, load float64) LoadShedderBuilder[R] {
+	c.thresholds[priority] = load
+	return c
+}
+
+func (c *loadShedderConfig[R]) WithDefaultPriority(priority Priority) LoadShedderBuilder[R] {
+	c.defaultPriority = priority
+	return c
+}
+
+func (c *loadShedderConfig[R]) OnShed(listener func(event ShedEvent[R])) LoadShedderBuilder[R] {
+	c.onShed = listener
+	return c
+}
+
+func (c *loadShedderConfig[R]) Build() LoadShedder[R] {
+	cCopy := *c
+	cCopy.thresholds = maps.Clone(c.thresholds)
+	return &loadShedder[R]{
+		config: &cCopy,
+	}
+}
+
+type loadShedder[R any] struct {
+	config *loadShedderConfig[R]
+}
+
+func (s *loadShedder[R]) TryAcquirePermit(priority Priority) bool {
+	_, acquired := s.tryAcquirePermit(priority)
+	return acquired
+}
+
+func (s *loadShedder[R]) ReleasePermit(duration time.Duration) {
+	s.config.signal.Finished(duration)
+}
+
+func (s *loadShedder[R]) Load() float64 {
+	return s.config.signal.Load()
+}
+
+func (s *loadShedder[R]) ToExecutor(_ R) any {
+	le := &loadShedderExecutor[R]{
+		BaseExecutor: &policy.BaseExecutor[R]{},
+		loadShedder:  s,
+	}
+	le.Executor = le
+	return le
+}
+
+// tryAcquirePermit returns the current load and whether a permit was acquired for the priority.
+func (s *loadShedder[R]) tryAcquirePermit(priority Priority) (float64, bool) {
+	threshold, ok := s.config.thresholds[priority]
+	if !ok {
+		threshold = s.config.thresholds[s.config.defaultPriority]
+	}
+	if signal, ok := s.config.signal.(admittingSignal); ok {
+		return signal.tryStart(threshold)
+	}
+	load := s.config.signal.Load()
+	if load >= threshold {
+		return load, false
+	}
+	s.config.signal.Started()
+	return load, true
+}
+
+func (s *loadShedder[R]) priorityFor(exec failsafe.Execution[R]) Priority {
+	if priority, ok := exec.Context().Value(PriorityKey).(Priority); ok {
+		return priority
+	}
+	return s.config.defaultPriority
+}
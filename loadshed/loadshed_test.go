@@ -0,0 +1,170 @@
+package loadshed
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+// Tests that lower priority executions are shed first as the load increases.
+func TestShouldShedLowerPrioritiesFirst(t *testing.T) {
+	load := 0.0
+	shedder := With[any](GaugeSignal(func() float64 {
+		return load
+	}))
+
+	load = .85
+	assert.False(t, shedder.TryAcquirePermit(LowPriority))
+	assert.True(t, shedder.TryAcquirePermit(MediumPriority))
+
+	load = .95
+	assert.False(t, shedder.TryAcquirePermit(MediumPriority))
+	assert.True(t, shedder.TryAcquirePermit(HighPriority))
+
+	load = 5
+	assert.False(t, shedder.TryAcquirePermit(HighPriority))
+	assert.True(t, shedder.TryAcquirePermit(CriticalPriority))
+}
+
+func TestWithThreshold(t *testing.T) {
+	shedder := Builder[any](GaugeSignal(func() float64 {
+		return 2
+	})).WithThreshold(CriticalPriority, 2).Build()
+
+	assert.False(t, shedder.TryAcquirePermit(CriticalPriority))
+}
+
+// Tests that executions are shed based on the priority in their Context, and that OnShed is called.
+func TestShouldShedExecutions(t *testing.T) {
+	// Given
+	var shedEvent ShedEvent[string]
+	shedder := Builder[string](GaugeSignal(func() float64 {
+		return .85
+	})).
+		OnShed(func(e ShedEvent[string]) {
+			shedEvent = e
+		}).
+		Build()
+	fn := func() (string, error) { return "foo", nil }
+	executor := failsafe.NewExecutor[string](shedder)
+
+	// When / Then
+	result, err := executor.Get(fn)
+	assert.Equal(t, "foo", result)
+	assert.Nil(t, err)
+
+	ctx := context.WithValue(context.Background(), PriorityKey, LowPriority)
+	_, err = executor.WithContext(ctx).Get(fn)
+	assert.ErrorIs(t, err, ErrShed)
+	assert.Equal(t, LowPriority, shedEvent.Priority)
+	assert.Equal(t, .85, shedEvent.Load)
+}
+
+func TestInFlightSignal(t *testing.T) {
+	shedder := With[any](InFlightSignal(10))
+	for i := 0; i < 9; i++ {
+		assert.True(t, shedder.TryAcquirePermit(HighPriority))
+	}
+	assert.Equal(t, .9, shedder.Load())
+	assert.False(t, shedder.TryAcquirePermit(MediumPriority))
+	assert.True(t, shedder.TryAcquirePermit(HighPriority))
+	assert.False(t, shedder.TryAcquirePermit(HighPriority))
+
+	shedder.ReleasePermit(time.Millisecond)
+	assert.True(t, shedder.TryAcquirePermit(HighPriority))
+}
+
+func TestCoDelSignal(t *testing.T) {
+	clock := &testutil.TestClock{CurrentTime: 1}
+	signal := CoDelSignal(10*time.Millisecond, 100*time.Millisecond).(*coDelSignal)
+	signal.clock = clock
+	assert.Equal(t, 0.0, signal.Load())
+
+	// Some executions are fast
+	signal.Finished(5 * time.Millisecond)
+	signal.Finished(50 * time.Millisecond)
+	clock.CurrentTime += testutil.MillisToNanos(100)
+	assert.Equal(t, .5, signal.Load())
+
+	// All executions are slow
+	signal.Finished(20 * time.Millisecond)
+	signal.Finished(30 * time.Millisecond)
+	clock.CurrentTime += testutil.MillisToNanos(100)
+	assert.Equal(t, 2.0, signal.Load())
+
+	// No executions finished
+	clock.CurrentTime += testutil.MillisToNanos(100)
+	assert.Equal(t, 1.0, signal.Load())
+	clock.CurrentTime += testutil.MillisToNanos(100)
+	assert.Equal(t, .5, signal.Load())
+}
+
+func TestInFlightSignalShouldValidate(t *testing.T) {
+	assert.Panics(t, func() {
+		InFlightSignal(0)
+	})
+}
+
+func TestCoDelSignalShouldValidate(t *testing.T) {
+	assert.Panics(t, func() {
+		CoDelSignal(0, 100*time.Millisecond)
+	})
+	assert.Panics(t, func() {
+		CoDelSignal(10*time.Millisecond, 0)
+	})
+}
+
+// Tests that the CoDel load is halved for each interval that elapses without executions finishing.
+func TestCoDelSignalShouldDecayForEachElapsedInterval(t *testing.T) {
+	// Given
+	clock := &testutil.TestClock{CurrentTime: 1}
+	signal := CoDelSignal(10*time.Millisecond, 100*time.Millisecond).(*coDelSignal)
+	signal.clock = clock
+	signal.Load()
+	signal.Finished(40 * time.Millisecond)
+
+	// When
+	clock.CurrentTime += testutil.MillisToNanos(300)
+
+	// Then
+	assert.Equal(t, 1.0, signal.Load())
+
+	// When
+	clock.CurrentTime += testutil.MillisToNanos(250)
+
+	// Then
+	assert.Equal(t, .25, signal.Load())
+	clock.CurrentTime += testutil.MillisToNanos(50)
+	assert.Equal(t, .125, signal.Load())
+}
+
+// Tests that a LoadShedder using an InFlightSignal does not admit more concurrent executions than its limit.
+func TestInFlightSignalWithConcurrentExecutions(t *testing.T) {
+	// Given
+	shedder := With[any](InFlightSignal(10))
+	var wg sync.WaitGroup
+	var acquired atomic.Int32
+
+	// When
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if shedder.TryAcquirePermit(HighPriority) {
+				acquired.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(10), acquired.Load())
+	assert.Equal(t, 1.0, shedder.Load())
+}
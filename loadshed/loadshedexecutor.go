@@ -0,0 +1,42 @@
+package loadshed
+
+import (
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/internal"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// loadShedderExecutor is a policy.Executor that handles failures according to a LoadShedder.
+type loadShedderExecutor[R any] struct {
+	*policy.BaseExecutor[R]
+	*loadShedder[R]
+}
+
+var _ policy.Executor[any] = &loadShedderExecutor[any]{}
+
+func (e *loadShedderExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		priority := e.priorityFor(exec)
+		load, acquired := e.tryAcquirePermit(priority)
+		if !acquired {
+			e.RecordEvent(exec.(policy.ExecutionInternal[R]), failsafe.PolicyEvent{Type: failsafe.LoadShed, Policy: "LoadShedder", Source: e.loadShedder, Error: ErrShed})
+			if e.config.onShed != nil {
+				e.config.onShed(ShedEvent[R]{
+					ExecutionEvent: failsafe.ExecutionEvent[R]{ExecutionAttempt: exec},
+					Priority:       priority,
+					Load:           load,
+				})
+			}
+			return internal.FailureResult[R](ErrShed)
+		}
+
+		startTime := time.Now()
+		defer func() {
+			e.ReleasePermit(time.Since(startTime))
+		}()
+		return innerFn(exec)
+	}
+}
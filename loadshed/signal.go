@@ -0,0 +1,176 @@
+package loadshed
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/internal/util"
+)
+
+// Signal reports the load on a system, where a load of 1 indicates the system is at capacity. Signals are notified when
+// executions start and finish so that they can track load.
+//
+// Implementations must be concurrency safe.
+type Signal interface {
+	// Load returns the current load.
+	Load() float64
+
+	// Started is called when an execution is admitted.
+	Started()
+
+	// Finished is called when an admitted execution finishes, with the duration of the execution.
+	Finished(duration time.Duration)
+}
+
+// InFlightSignal returns a Signal whose load is the number of in-flight executions divided by the limit. Executions are
+// admitted atomically, so that concurrent executions cannot exceed a LoadShedder's threshold.
+//
+// Panics if limit is 0.
+func InFlightSignal(limit uint) Signal {
+	util.Assert(limit > 0, "limit must be > 0")
+	return &inFlightSignal{limit: limit}
+}
+
+// admittingSignal is a Signal that can atomically check its load against a threshold and start an execution.
+type admittingSignal interface {
+	Signal
+
+	// tryStart starts an execution if the load is below the threshold, returning the load and whether the execution was
+	// started.
+	tryStart(threshold float64) (float64, bool)
+}
+
+type inFlightSignal struct {
+	limit    uint
+	inFlight atomic.Int64
+}
+
+var _ admittingSignal = &inFlightSignal{}
+
+func (s *inFlightSignal) Load() float64 {
+	return s.loadFor(s.inFlight.Load())
+}
+
+func (s *inFlightSignal) Started() {
+	s.inFlight.Add(1)
+}
+
+func (s *inFlightSignal) Finished(_ time.Duration) {
+	s.inFlight.Add(-1)
+}
+
+func (s *inFlightSignal) tryStart(threshold float64) (float64, bool) {
+	for {
+		inFlight := s.inFlight.Load()
+		load := s.loadFor(inFlight)
+		if load >= threshold {
+			return load, false
+		}
+		if s.inFlight.CompareAndSwap(inFlight, inFlight+1) {
+			return load, true
+		}
+	}
+}
+
+func (s *inFlightSignal) loadFor(inFlight int64) float64 {
+	return float64(inFlight) / float64(s.limit)
+}
+
+/*
+CoDelSignal returns a Signal that detects queueing based on the CoDel algorithm. The signal tracks the minimum duration of
+executions that finish within each interval, and its load is the ratio of the minimum duration in the last interval to
+the target. Since some executions finish quickly when a system is not overloaded, the minimum duration only exceeds the
+target when executions consistently queue. The load is halved for each interval in which no executions finish, so that
+a LoadShedder that is shedding executions will eventually admit some.
+
+Durations are measured from when an execution is admitted until it finishes, so they include the time spent performing
+the execution along with any time spent queueing, such as waiting for a Bulkhead permit when a LoadShedder is composed
+outside of a Bulkhead. The target should therefore be somewhat higher than the minimum duration of executions when a
+system is healthy. For example, if executions normally take at least 50ms, a target of 60ms and an interval of 100ms
+would shed load when executions consistently queue for more than 10ms.
+
+Panics if target or interval are <= 0.
+*/
+func CoDelSignal(target time.Duration, interval time.Duration) Signal {
+	util.Assert(target > 0, "target must be > 0")
+	util.Assert(interval > 0, "interval must be > 0")
+	return &coDelSignal{
+		clock:    util.NewClock(),
+		target:   target,
+		interval: interval,
+	}
+}
+
+type coDelSignal struct {
+	clock    util.Clock
+	target   time.Duration
+	interval time.Duration
+	mtx      sync.Mutex
+
+	// Guarded by mtx
+	intervalStart int64
+	minDuration   time.Duration
+	load          float64
+}
+
+func (s *coDelSignal) Load() float64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.rollInterval()
+	return s.load
+}
+
+func (s *coDelSignal) Started() {
+}
+
+func (s *coDelSignal) Finished(duration time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.rollInterval()
+	if s.minDuration == 0 || duration < s.minDuration {
+		s.minDuration = duration
+	}
+}
+
+// rollInterval computes the load and starts a new interval if the current interval has elapsed. The load is halved for
+// each elapsed interval in which no executions finished.
+//
+// Requires external locking.
+func (s *coDelSignal) rollInterval() {
+	now := s.clock.CurrentUnixNano()
+	if s.intervalStart == 0 {
+		s.intervalStart = now
+		return
+	}
+	elapsed := (now - s.intervalStart) / s.interval.Nanoseconds()
+	if elapsed == 0 {
+		return
+	}
+	idleIntervals := elapsed
+	if s.minDuration > 0 {
+		s.load = float64(s.minDuration) / float64(s.target)
+		idleIntervals--
+	}
+	s.load /= math.Pow(2, float64(idleIntervals))
+	s.intervalStart += elapsed * s.interval.Nanoseconds()
+	s.minDuration = 0
+}
+
+// GaugeSignal returns a Signal whose load is provided by the gauge, such as CPU or memory utilization.
+func GaugeSignal(gauge func() float64) Signal {
+	return gaugeSignal(gauge)
+}
+
+type gaugeSignal func() float64
+
+func (s gaugeSignal) Load() float64 {
+	return s()
+}
+
+func (s gaugeSignal) Started() {
+}
+
+func (s gaugeSignal) Finished(_ time.Duration) {
+}
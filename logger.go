@@ -0,0 +1,184 @@
+package failsafe
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/failsafe-go/failsafe-go/internal/util"
+)
+
+// LoggerBuilder builds Loggers.
+//
+// This type is not concurrency safe.
+type LoggerBuilder interface {
+	// WithLevel configures the level that events of the eventType are logged at. See Logger for the default levels.
+	WithLevel(eventType PolicyEventType, level slog.Level) LoggerBuilder
+
+	// WithSampleRate configures the fraction of executions, from 0 to 1, whose events are logged. Executions are sampled
+	// based on their ID, so that either all or none of an execution's events are logged, by any Logger with the same sample
+	// rate. Defaults to 1, which logs events for every execution.
+	//
+	// Panics if the rate is not from 0 to 1.
+	WithSampleRate(rate float64) LoggerBuilder
+
+	// Build returns a new Logger using the builder's configuration.
+	Build() *Logger
+}
+
+type loggerConfig struct {
+	logger     *slog.Logger
+	levels     map[PolicyEventType]slog.Level
+	sampleRate float64
+}
+
+var _ LoggerBuilder = &loggerConfig{}
+
+var defaultLevels = map[PolicyEventType]slog.Level{
+	RetryScheduled:             slog.LevelInfo,
+	RetriesExceeded:            slog.LevelWarn,
+	RetryAborted:               slog.LevelWarn,
+	HedgeStarted:               slog.LevelInfo,
+	HedgeSkipped:               slog.LevelDebug,
+	FallbackExecuted:           slog.LevelInfo,
+	CircuitBreakerRejected:     slog.LevelDebug,
+	CircuitBreakerStateChanged: slog.LevelWarn,
+	TimeoutExceeded:            slog.LevelWarn,
+	BulkheadFull:               slog.LevelWarn,
+	RateLimitExceeded:          slog.LevelWarn,
+	Throttled:                  slog.LevelDebug,
+	LoadShed:                   slog.LevelDebug,
+	CacheHit:                   slog.LevelDebug,
+	CacheMiss:                  slog.LevelDebug,
+	FaultInjected:              slog.LevelInfo,
+}
+
+/*
+Logger is an Observer that logs policy events as structured records via a slog.Logger. Each record's message describes
+the event, such as "retry scheduled", and the record contains these attributes:
+
+  - execution_id: the execution's ID, which is the same for all of its attempts
+  - policy: the type of policy that the event is for, such as RetryPolicy
+  - attempts, executions, retries, and hedges: the execution's attempt counters
+  - target: the attempt's target, if targets are configured
+  - delay: the delay before the next attempt, for RetryScheduled events, or the injected latency, for FaultInjected events
+  - old_state and new_state: the CircuitBreaker's states, for CircuitBreakerStateChanged events
+  - error: the error that caused the event, if any
+
+By default, RetriesExceeded, RetryAborted, CircuitBreakerStateChanged, TimeoutExceeded, BulkheadFull, and
+RateLimitExceeded events are logged at slog.LevelWarn, RetryScheduled, HedgeStarted, FallbackExecuted, and FaultInjected
+events are logged at slog.LevelInfo, and other events are logged at slog.LevelDebug.
+
+A Logger can be configured for all of an Executor's policies via the Executor's WithObserver or WithLogger. A policy can
+also be configured to log only its own events, with the default levels, via its builder's WithLogger. Since these are
+separate Loggers, an event will be logged twice if both an Executor and its policy are configured with loggers.
+
+This type is concurrency safe.
+*/
+type Logger struct {
+	config *loggerConfig
+}
+
+var _ Observer = &Logger{}
+
+// NewLoggerBuilder returns a new LoggerBuilder that builds Loggers that log via the logger.
+func NewLoggerBuilder(logger *slog.Logger) LoggerBuilder {
+	return &loggerConfig{
+		logger:     logger,
+		levels:     make(map[PolicyEventType]slog.Level),
+		sampleRate: 1,
+	}
+}
+
+func (c *loggerConfig) WithLevel(eventType PolicyEventType, level slog.Level) LoggerBuilder {
+	c.levels[eventType] = level
+	return c
+}
+
+func (c *loggerConfig) WithSampleRate(rate float64) LoggerBuilder {
+	util.Assert(rate >= 0 && rate <= 1, "rate must be from 0 to 1")
+	c.sampleRate = rate
+	return c
+}
+
+func (c *loggerConfig) Build() *Logger {
+	config := *c
+	config.levels = make(map[PolicyEventType]slog.Level, len(defaultLevels))
+	for eventType, level := range defaultLevels {
+		config.levels[eventType] = level
+	}
+	for eventType, level := range c.levels {
+		config.levels[eventType] = level
+	}
+	return &Logger{config: &config}
+}
+
+func (l *Logger) ExecutionStarted(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (l *Logger) AttemptStarted(ctx context.Context, _ AttemptStats) context.Context {
+	return ctx
+}
+
+func (l *Logger) AttemptDone(context.Context, AttemptStats, error) {
+}
+
+// PolicyEvent logs the event, if its level is enabled and its execution is sampled. The event's AttemptStats may be nil
+// for events that did not occur during an execution, in which case only the event's attributes are logged.
+func (l *Logger) PolicyEvent(ctx context.Context, event PolicyEvent) {
+	level, ok := l.config.levels[event.Type]
+	if !ok {
+		level = slog.LevelDebug
+	}
+	if !l.config.logger.Enabled(ctx, level) {
+		return
+	}
+	if event.AttemptStats != nil && !l.sampled(event.ID()) {
+		return
+	}
+
+	attrs := make([]slog.Attr, 0, 10)
+	if event.AttemptStats != nil {
+		attrs = append(attrs, slog.String("execution_id", event.ID()))
+	}
+	attrs = append(attrs, slog.String("policy", event.Policy))
+	if event.AttemptStats != nil {
+		attrs = append(attrs,
+			slog.Int("attempts", event.Attempts()),
+			slog.Int("executions", event.Executions()),
+			slog.Int("retries", event.Retries()),
+			slog.Int("hedges", event.Hedges()))
+		if target := event.Target(); target != "" {
+			attrs = append(attrs, slog.String("target", target))
+		}
+	}
+	if event.Type == RetryScheduled || event.Type == FaultInjected && event.Delay > 0 {
+		attrs = append(attrs, slog.Duration("delay", event.Delay))
+	}
+	if event.Type == CircuitBreakerStateChanged {
+		attrs = append(attrs, slog.String("old_state", event.OldState), slog.String("new_state", event.NewState))
+	}
+	if event.Error != nil {
+		attrs = append(attrs, slog.Any("error", event.Error))
+	}
+	l.config.logger.LogAttrs(ctx, level, strings.ReplaceAll(event.Type.String(), "_", " "), attrs...)
+}
+
+func (l *Logger) ExecutionDone(context.Context, ExecutionStats, error) {
+}
+
+// sampled returns whether events for the execution with the id should be logged.
+func (l *Logger) sampled(id string) bool {
+	if l.config.sampleRate >= 1 {
+		return true
+	}
+	if l.config.sampleRate <= 0 {
+		return false
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(id))
+	return float64(hash.Sum64())/math.MaxUint64 < l.config.sampleRate
+}
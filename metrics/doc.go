@@ -0,0 +1,3 @@
+// Package metrics provides a Registry of named policies, and an Observer that records metrics for executions and
+// policies to a Recorder, such as one that's backed by OpenTelemetry or Prometheus.
+package metrics
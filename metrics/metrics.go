@@ -0,0 +1,170 @@
+package metrics
+
+// Counter is a type of counter metric.
+type Counter int
+
+const (
+	// Executions counts executions, labeled by Outcome.
+	Executions Counter = iota + 1
+
+	// Retries counts retries that were scheduled by RetryPolicies, labeled by Policy.
+	Retries
+
+	// Hedges counts hedged attempts that were started by HedgePolicies, labeled by Policy.
+	Hedges
+
+	// Rejections counts attempts that were rejected by CircuitBreakers, Bulkheads, RateLimiters, AdaptiveThrottlers, and
+	// LoadShedders, labeled by Policy.
+	Rejections
+
+	// CacheHits counts results that were returned from a CachePolicy's cache, labeled by Policy.
+	CacheHits
+
+	// CacheMisses counts results that were not found in a CachePolicy's cache, labeled by Policy.
+	CacheMisses
+
+	// Timeouts counts Timeouts that were exceeded, labeled by Policy.
+	Timeouts
+)
+
+var counterNames = map[Counter]string{
+	Executions:  "executions",
+	Retries:     "retries",
+	Hedges:      "hedges",
+	Rejections:  "rejections",
+	CacheHits:   "cache_hits",
+	CacheMisses: "cache_misses",
+	Timeouts:    "timeouts",
+}
+
+var counterDescriptions = map[Counter]string{
+	Executions:  "The number of executions.",
+	Retries:     "The number of retries that were scheduled.",
+	Hedges:      "The number of hedged attempts that were started.",
+	Rejections:  "The number of attempts that were rejected.",
+	CacheHits:   "The number of cache hits.",
+	CacheMisses: "The number of cache misses.",
+	Timeouts:    "The number of timeouts that were exceeded.",
+}
+
+// Counters returns all counter types.
+func Counters() []Counter {
+	return []Counter{Executions, Retries, Hedges, Rejections, CacheHits, CacheMisses, Timeouts}
+}
+
+// String returns the name of the counter in snake case, such as cache_hits.
+func (c Counter) String() string {
+	return nameOf(counterNames, c)
+}
+
+// Description returns a description of the counter.
+func (c Counter) Description() string {
+	return counterDescriptions[c]
+}
+
+// Histogram is a type of histogram metric. Histogram values are recorded in seconds.
+type Histogram int
+
+const (
+	// AttemptLatency records the duration of execution attempts, labeled by Outcome.
+	AttemptLatency Histogram = iota + 1
+
+	// RetryDelay records the delays before retries, labeled by Policy.
+	RetryDelay
+)
+
+var histogramNames = map[Histogram]string{
+	AttemptLatency: "attempt_latency",
+	RetryDelay:     "retry_delay",
+}
+
+var histogramDescriptions = map[Histogram]string{
+	AttemptLatency: "The duration of execution attempts.",
+	RetryDelay:     "The delay before retries.",
+}
+
+// Histograms returns all histogram types.
+func Histograms() []Histogram {
+	return []Histogram{AttemptLatency, RetryDelay}
+}
+
+// String returns the name of the histogram in snake case, such as attempt_latency.
+func (h Histogram) String() string {
+	return nameOf(histogramNames, h)
+}
+
+// Description returns a description of the histogram.
+func (h Histogram) Description() string {
+	return histogramDescriptions[h]
+}
+
+// Gauge is a type of gauge metric, which reports the current state of a registered policy.
+type Gauge int
+
+const (
+	// CircuitBreakerState reports the state of a CircuitBreaker, where 0 is closed, 1 is open, and 2 is half-open, labeled
+	// by Policy.
+	CircuitBreakerState Gauge = iota + 1
+
+	// BulkheadInFlight reports the number of executions that are in flight within a Bulkhead, labeled by Policy.
+	BulkheadInFlight
+
+	// RateLimiterWait reports the time, in seconds, that an execution would currently need to wait for a RateLimiter
+	// permit, labeled by Policy.
+	RateLimiterWait
+)
+
+var gaugeNames = map[Gauge]string{
+	CircuitBreakerState: "circuit_breaker_state",
+	BulkheadInFlight:    "bulkhead_in_flight",
+	RateLimiterWait:     "rate_limiter_wait",
+}
+
+var gaugeDescriptions = map[Gauge]string{
+	CircuitBreakerState: "The state of a circuit breaker, where 0 is closed, 1 is open, and 2 is half-open.",
+	BulkheadInFlight:    "The number of executions that are in flight within a bulkhead.",
+	RateLimiterWait:     "The time that an execution would currently wait for a rate limiter permit.",
+}
+
+// Gauges returns all gauge types.
+func Gauges() []Gauge {
+	return []Gauge{CircuitBreakerState, BulkheadInFlight, RateLimiterWait}
+}
+
+// String returns the name of the gauge in snake case, such as bulkhead_in_flight.
+func (g Gauge) String() string {
+	return nameOf(gaugeNames, g)
+}
+
+// Description returns a description of the gauge.
+func (g Gauge) Description() string {
+	return gaugeDescriptions[g]
+}
+
+// InSeconds returns whether the gauge's values are in seconds.
+func (g Gauge) InSeconds() bool {
+	return g == RateLimiterWait
+}
+
+// Outcomes of executions and attempts.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Labels are the labels for a metric value. Executions and AttemptLatency are labeled by Outcome, and other metrics are
+// labeled by Policy.
+type Labels struct {
+	// The name of the policy that a metric is for, else the type of the policy, such as RetryPolicy, if it's not
+	// registered.
+	Policy string
+	// The outcome of an execution or attempt, either OutcomeSuccess or OutcomeFailure.
+	Outcome string
+}
+
+func nameOf[T comparable](names map[T]string, t T) string {
+	if name, ok := names[t]; ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,208 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/cachepolicy"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+func TestObserverWithRetries(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+	rp := retrypolicy.Builder[bool]().WithDelay(10 * time.Millisecond).Build()
+	registry.Register("payments", rp)
+	recorder := &testRecorder{}
+	stub, _ := testutil.ErrorNTimesThenReturn[bool](testutil.ErrInvalidState, 2, true)
+
+	// When
+	_, err := failsafe.NewExecutor[bool](rp).WithObserver(NewObserver(registry, recorder)).GetWithExecution(stub)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"retries payments":   2,
+		"executions success": 1,
+	}, recorder.Counters())
+	assert.Equal(t, map[string][]float64{
+		"retry_delay payments": {.01, .01},
+	}, recorder.Histograms(RetryDelay))
+	assert.Len(t, recorder.Histograms(AttemptLatency)["attempt_latency failure"], 2)
+	assert.Len(t, recorder.Histograms(AttemptLatency)["attempt_latency success"], 1)
+}
+
+// Tests that policies that are not registered are labeled with their type.
+func TestObserverWithRejections(t *testing.T) {
+	// Given
+	cb := circuitbreaker.WithDefaults[any]()
+	cb.Open()
+	recorder := &testRecorder{}
+	executor := failsafe.NewExecutor[any](cb).WithObserver(NewObserver(nil, recorder))
+
+	// When
+	err1 := executor.RunWithExecution(testutil.RunFn(nil))
+	err2 := executor.RunWithExecution(testutil.RunFn(nil))
+
+	// Then
+	assert.ErrorIs(t, err1, circuitbreaker.ErrOpen)
+	assert.ErrorIs(t, err2, circuitbreaker.ErrOpen)
+	assert.Equal(t, map[string]int{
+		"rejections CircuitBreaker": 2,
+		"executions failure":        2,
+	}, recorder.Counters())
+}
+
+func TestObserverWithTimeoutsAndFallback(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+	to := timeout.With[any](10 * time.Millisecond)
+	registry.Register("slow", to)
+	fb := fallback.WithResult[any](nil)
+	recorder := &testRecorder{}
+
+	// When
+	err := failsafe.NewExecutor[any](fb, to).WithObserver(NewObserver(registry, recorder)).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		<-exec.Canceled()
+		return nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"timeouts slow":      1,
+		"executions success": 1,
+	}, recorder.Counters())
+}
+
+func TestObserverWithCache(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+	cp := cachepolicy.Builder[string](cachepolicy.LRU[string](10)).WithKey("foo").Build()
+	registry.Register("users", cp)
+	executor := failsafe.NewExecutor[string](cp)
+	recorder := &testRecorder{}
+	executor = executor.WithObserver(NewObserver(registry, recorder))
+
+	// When
+	executor.Get(func() (string, error) { return "bar", nil })
+	executor.Get(func() (string, error) { return "bar", nil })
+
+	// Then
+	assert.Equal(t, map[string]int{
+		"cache_misses users": 1,
+		"cache_hits users":   1,
+		"executions success": 2,
+	}, recorder.Counters())
+}
+
+func TestRegistryCollectGauges(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+	cb := circuitbreaker.WithDefaults[any]()
+	cb.Open()
+	bh := bulkhead.With[any](2)
+	bh.TryAcquirePermit()
+	rl := ratelimiter.SmoothBuilderWithMaxRate[any](time.Minute).Build()
+	rl.TryAcquirePermit()
+	registry.Register("cb", cb)
+	registry.Register("bh", bh)
+	registry.Register("rl", rl)
+	registry.Register("rp", retrypolicy.WithDefaults[any]())
+
+	// When
+	values := make(map[string]float64)
+	registry.CollectGauges(func(gauge Gauge, labels Labels, value float64) {
+		values[fmt.Sprintf("%s %s", gauge, labels.Policy)] = value
+	})
+
+	// Then
+	assert.Len(t, values, 3)
+	assert.Equal(t, float64(circuitbreaker.OpenState), values["circuit_breaker_state cb"])
+	assert.Equal(t, float64(1), values["bulkhead_in_flight bh"])
+	assert.InDelta(t, 60, values["rate_limiter_wait rl"], 1)
+}
+
+func TestRegistryRegister(t *testing.T) {
+	registry := NewRegistry()
+	rp := retrypolicy.WithDefaults[any]()
+	assert.Equal(t, "", registry.Name(rp))
+
+	registry.Register("foo", rp)
+	registry.Register("bar", rp)
+	assert.Equal(t, "bar", registry.Name(rp))
+	assert.Equal(t, "", registry.Name(retrypolicy.WithDefaults[any]()))
+	assert.Equal(t, "", (*Registry)(nil).Name(rp))
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "cache_hits", CacheHits.String())
+	assert.Equal(t, "retry_delay", RetryDelay.String())
+	assert.Equal(t, "bulkhead_in_flight", BulkheadInFlight.String())
+	assert.Equal(t, "unknown", Counter(0).String())
+	for _, counter := range Counters() {
+		assert.NotEmpty(t, counter.Description())
+	}
+	for _, histogram := range Histograms() {
+		assert.NotEmpty(t, histogram.Description())
+	}
+	for _, gauge := range Gauges() {
+		assert.NotEmpty(t, gauge.Description())
+	}
+}
+
+type testRecorder struct {
+	mtx        sync.Mutex
+	counters   map[string]int
+	histograms map[Histogram]map[string][]float64
+}
+
+func (r *testRecorder) IncrementCounter(_ context.Context, counter Counter, labels Labels) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.counters == nil {
+		r.counters = make(map[string]int)
+	}
+	r.counters[key(counter.String(), labels)]++
+}
+
+func (r *testRecorder) RecordHistogram(_ context.Context, histogram Histogram, labels Labels, value float64) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.histograms == nil {
+		r.histograms = make(map[Histogram]map[string][]float64)
+	}
+	if r.histograms[histogram] == nil {
+		r.histograms[histogram] = make(map[string][]float64)
+	}
+	k := key(histogram.String(), labels)
+	r.histograms[histogram][k] = append(r.histograms[histogram][k], value)
+}
+
+func (r *testRecorder) Counters() map[string]int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.counters
+}
+
+func (r *testRecorder) Histograms(histogram Histogram) map[string][]float64 {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.histograms[histogram]
+}
+
+func key(name string, labels Labels) string {
+	return name + " " + labels.Policy + labels.Outcome
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// Recorder records metric values to a metrics backend. The ctx is the context of the execution or attempt that a value
+// is for, which backends can use to associate values with traces.
+//
+// Implementations must be concurrency safe.
+type Recorder interface {
+	// IncrementCounter increments the counter with the labels by 1.
+	IncrementCounter(ctx context.Context, counter Counter, labels Labels)
+
+	// RecordHistogram records the value, in seconds, to the histogram with the labels.
+	RecordHistogram(ctx context.Context, histogram Histogram, labels Labels, value float64)
+}
+
+type observer struct {
+	registry *Registry
+	recorder Recorder
+}
+
+var _ failsafe.Observer = &observer{}
+
+// NewObserver returns a failsafe.Observer that records counter and histogram metrics for executions, attempts, and
+// policy events to the recorder. Policy metrics are labeled with the names of policies in the registry, else with the
+// type of the policy if it's not registered. The registry may be nil.
+func NewObserver(registry *Registry, recorder Recorder) failsafe.Observer {
+	return &observer{
+		registry: registry,
+		recorder: recorder,
+	}
+}
+
+func (o *observer) ExecutionStarted(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (o *observer) AttemptStarted(ctx context.Context, _ failsafe.AttemptStats) context.Context {
+	return ctx
+}
+
+func (o *observer) AttemptDone(ctx context.Context, attempt failsafe.AttemptStats, err error) {
+	o.recorder.RecordHistogram(ctx, AttemptLatency, Labels{Outcome: outcome(err)}, attempt.ElapsedAttemptTime().Seconds())
+}
+
+func (o *observer) PolicyEvent(ctx context.Context, event failsafe.PolicyEvent) {
+	labels := Labels{Policy: o.registry.Name(event.Source)}
+	if labels.Policy == "" {
+		labels.Policy = event.Policy
+	}
+
+	switch event.Type {
+	case failsafe.RetryScheduled:
+		o.recorder.IncrementCounter(ctx, Retries, labels)
+		o.recorder.RecordHistogram(ctx, RetryDelay, labels, event.Delay.Seconds())
+	case failsafe.HedgeStarted:
+		o.recorder.IncrementCounter(ctx, Hedges, labels)
+	case failsafe.CircuitBreakerRejected, failsafe.BulkheadFull, failsafe.RateLimitExceeded, failsafe.Throttled, failsafe.LoadShed:
+		o.recorder.IncrementCounter(ctx, Rejections, labels)
+	case failsafe.CacheHit:
+		o.recorder.IncrementCounter(ctx, CacheHits, labels)
+	case failsafe.CacheMiss:
+		o.recorder.IncrementCounter(ctx, CacheMisses, labels)
+	case failsafe.TimeoutExceeded:
+		o.recorder.IncrementCounter(ctx, Timeouts, labels)
+	}
+}
+
+func (o *observer) ExecutionDone(ctx context.Context, _ failsafe.ExecutionStats, err error) {
+	o.recorder.IncrementCounter(ctx, Executions, Labels{Outcome: outcome(err)})
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+)
+
+// Registry contains named policies. Metrics for registered policies are labeled with their name, and registered
+// CircuitBreakers, Bulkheads, and RateLimiters have their state reported as gauges. Metrics for policies that are not
+// registered are labeled with the type of the policy, such as RetryPolicy.
+//
+// This type is concurrency safe.
+type Registry struct {
+	mtx      sync.RWMutex
+	names    map[any]string // Guarded by mtx
+	policies []any          // Guarded by mtx
+}
+
+// NewRegistry returns a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		names: make(map[any]string),
+	}
+}
+
+// Register registers the policy with the name, replacing any name the policy was previously registered with. The policy
+// must be a failsafe.Policy, such as a RetryPolicy or CircuitBreaker. Multiple policies can be registered with the same
+// name, such as a RetryPolicy and CircuitBreaker that protect the same dependency, since their metrics are distinct, but
+// policies of the same type should have distinct names.
+func (r *Registry) Register(name string, policy any) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.names[policy]; !ok {
+		r.policies = append(r.policies, policy)
+	}
+	r.names[policy] = name
+}
+
+// Name returns the name that the policy is registered with, else "" if it's not registered.
+func (r *Registry) Name(policy any) string {
+	if r == nil || policy == nil {
+		return ""
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.names[policy]
+}
+
+// CollectGauges calls the fn with the current value of each gauge for the registered policies.
+func (r *Registry) CollectGauges(fn func(gauge Gauge, labels Labels, value float64)) {
+	if r == nil {
+		return
+	}
+	r.mtx.RLock()
+	names := make([]string, len(r.policies))
+	policies := make([]any, len(r.policies))
+	for i, policy := range r.policies {
+		names[i] = r.names[policy]
+		policies[i] = policy
+	}
+	r.mtx.RUnlock()
+
+	for i, policy := range policies {
+		labels := Labels{Policy: names[i]}
+		switch p := policy.(type) {
+		case interface{ State() circuitbreaker.State }:
+			fn(CircuitBreakerState, labels, float64(p.State()))
+		case interface{ Metrics() bulkhead.Metrics }:
+			fn(BulkheadInFlight, labels, float64(p.Metrics().InFlight()))
+		case interface{ Metrics() ratelimiter.Metrics }:
+			fn(RateLimiterWait, labels, p.Metrics().WaitTime().Seconds())
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package failsafe
+
+import (
+	"context"
+	"time"
+)
+
+// Observer observes executions, their attempts, and events from their policies. Observers can be used to integrate
+// executions with tracing, metrics, or logging, without needing to register listeners with each policy. See
+// Executor.WithObserver.
+//
+// Observer methods are called synchronously, and should not block. Implementations must be concurrency safe.
+type Observer interface {
+	// ExecutionStarted is called when an execution starts, and returns the context to perform the execution with. This can
+	// be used to store per execution state in the context, such as a span, which will be available to the other Observer
+	// methods via their ctx.
+	ExecutionStarted(ctx context.Context) context.Context
+
+	// AttemptStarted is called before an execution attempt, including retries and hedges, is performed, and returns the
+	// context to perform the attempt with. The returned context is provided to the attempt via Execution.Context, and should
+	// be derived from the ctx. Attempts that are rejected by a policy before they're performed, such as by an open
+	// CircuitBreaker, are not started.
+	AttemptStarted(ctx context.Context, attempt AttemptStats) context.Context
+
+	// AttemptDone is called when an execution attempt is done, with the ctx that was returned from AttemptStarted, and the
+	// error returned by the attempt, if any.
+	AttemptDone(ctx context.Context, attempt AttemptStats, err error)
+
+	// PolicyEvent is called when a policy event occurs during an execution, with the context of the execution.
+	PolicyEvent(ctx context.Context, event PolicyEvent)
+
+	// ExecutionDone is called when an execution is done, with the ctx that was returned from ExecutionStarted, and the
+	// execution's error, if any.
+	ExecutionDone(ctx context.Context, stats ExecutionStats, err error)
+}
+
+// PolicyEventType is a type of PolicyEvent.
+type PolicyEventType int
+
+const (
+	// RetryScheduled indicates that a RetryPolicy scheduled a retry after a failed attempt.
+	RetryScheduled PolicyEventType = iota + 1
+
+	// RetriesExceeded indicates that a RetryPolicy's max retries or max duration were exceeded.
+	RetriesExceeded
+
+	// RetryAborted indicates that a RetryPolicy aborted retries because of an abort condition.
+	RetryAborted
+
+	// HedgeStarted indicates that a HedgePolicy started a hedged attempt.
+	HedgeStarted
+
+	// HedgeSkipped indicates that a HedgePolicy skipped a hedge because it would exceed the hedge budget.
+	HedgeSkipped
+
+	// FallbackExecuted indicates that a Fallback was executed after a failure.
+	FallbackExecuted
+
+	// CircuitBreakerRejected indicates that a CircuitBreaker rejected an attempt because it was open.
+	CircuitBreakerRejected
+
+	// CircuitBreakerStateChanged indicates that a CircuitBreaker transitioned to a new state during an execution.
+	CircuitBreakerStateChanged
+
+	// TimeoutExceeded indicates that a Timeout was exceeded.
+	TimeoutExceeded
+
+	// BulkheadFull indicates that a Bulkhead rejected an attempt because it was full.
+	BulkheadFull
+
+	// RateLimitExceeded indicates that a RateLimiter rejected an attempt because its rate limit was exceeded.
+	RateLimitExceeded
+
+	// Throttled indicates that an AdaptiveThrottler rejected an attempt.
+	Throttled
+
+	// LoadShed indicates that a LoadShedder rejected an attempt.
+	LoadShed
+
+	// CacheHit indicates that a CachePolicy returned a cached result.
+	CacheHit
+
+	// CacheMiss indicates that a CachePolicy did not find a cached result.
+	CacheMiss
+
+	// FaultInjected indicates that a Chaos policy injected a fault into an attempt.
+	FaultInjected
+)
+
+var policyEventTypeNames = map[PolicyEventType]string{
+	RetryScheduled:             "retry_scheduled",
+	RetriesExceeded:            "retries_exceeded",
+	RetryAborted:               "retry_aborted",
+	HedgeStarted:               "hedge_started",
+	HedgeSkipped:               "hedge_skipped",
+	FallbackExecuted:           "fallback_executed",
+	CircuitBreakerRejected:     "circuit_breaker_rejected",
+	CircuitBreakerStateChanged: "circuit_breaker_state_changed",
+	TimeoutExceeded:            "timeout_exceeded",
+	BulkheadFull:               "bulkhead_full",
+	RateLimitExceeded:          "rate_limit_exceeded",
+	Throttled:                  "throttled",
+	LoadShed:                   "load_shed",
+	CacheHit:                   "cache_hit",
+	CacheMiss:                  "cache_miss",
+	FaultInjected:              "fault_injected",
+}
+
+// String returns the name of the event type in snake case, such as retry_scheduled.
+func (t PolicyEventType) String() string {
+	if name, ok := policyEventTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// PolicyEvent is an event from a policy that occurred during an execution.
+type PolicyEvent struct {
+	// Stats for the execution attempt that the event occurred for.
+	AttemptStats
+	// The type of event.
+	Type PolicyEventType
+	// The type of policy that the event is for, such as RetryPolicy.
+	Policy string
+	// The policy that the event is for, such as a RetryPolicy instance.
+	Source any
+	// The delay before the next attempt, for RetryScheduled events, or the injected latency, for FaultInjected events,
+	// else 0.
+	Delay time.Duration
+	// The previous state of a CircuitBreaker, such as closed, for CircuitBreakerStateChanged events, else empty.
+	OldState string
+	// The new state of a CircuitBreaker, such as open, for CircuitBreakerStateChanged events, else empty.
+	NewState string
+	// The error that caused the event, if any.
+	Error error
+}
+
+// observers is an Observer that notifies multiple observers.
+type observers []Observer
+
+func (o observers) ExecutionStarted(ctx context.Context) context.Context {
+	for _, observer := range o {
+		ctx = observer.ExecutionStarted(ctx)
+	}
+	return ctx
+}
+
+func (o observers) AttemptStarted(ctx context.Context, attempt AttemptStats) context.Context {
+	for _, observer := range o {
+		ctx = observer.AttemptStarted(ctx, attempt)
+	}
+	return ctx
+}
+
+func (o observers) AttemptDone(ctx context.Context, attempt AttemptStats, err error) {
+	for _, observer := range o {
+		observer.AttemptDone(ctx, attempt, err)
+	}
+}
+
+func (o observers) PolicyEvent(ctx context.Context, event PolicyEvent) {
+	for _, observer := range o {
+		observer.PolicyEvent(ctx, event)
+	}
+}
+
+func (o observers) ExecutionDone(ctx context.Context, stats ExecutionStats, err error) {
+	for _, observer := range o {
+		observer.ExecutionDone(ctx, stats, err)
+	}
+}
@@ -16,9 +16,20 @@ type ExecutionInternal[R any] interface {
 	// cancellation result is returned.
 	InitializeRetry() *common.PolicyResult[R]
 
+	// RecordEvent notifies any Observer of the execution of the policy event, along with stats for the current attempt.
+	RecordEvent(event failsafe.PolicyEvent)
+
 	// Cancel cancels the execution with the result.
 	Cancel(result *common.PolicyResult[R]) *common.PolicyResult[R]
 
+	// Discard cancels the execution without a result, marking it and any executions that use its Context as discarded, such
+	// as when a hedge loses to another attempt. If the execution was already canceled, the cancellation result is returned
+	// and the execution is not marked as discarded.
+	Discard() *common.PolicyResult[R]
+
+	// IsDiscarded returns whether the execution, or an execution whose Context it uses, was discarded.
+	IsDiscarded() bool
+
 	// IsCanceledWithResult returns whether the execution is canceled, along with the cancellation result, if any.
 	IsCanceledWithResult() (bool, *common.PolicyResult[R])
 
@@ -32,4 +43,9 @@ type ExecutionInternal[R any] interface {
 
 	// CopyForHedge creates a copy of the execution marked as a hedge.
 	CopyForHedge() failsafe.Execution[R]
+
+	// CopyForBackground creates a new execution with fresh stats and a context that is not canceled when the current
+	// execution's context is canceled. This is useful for work that continues in the background after an execution is done.
+	// The new execution should be canceled when its work is done, to release its context.
+	CopyForBackground() failsafe.Execution[R]
 }
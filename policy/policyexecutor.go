@@ -37,10 +37,21 @@ type Executor[R any] interface {
 type BaseExecutor[R any] struct {
 	Executor[R]
 	*BaseFailurePolicy[R]
+	// The policy's Logger, if any.
+	Logger *failsafe.Logger
 }
 
 var _ Executor[any] = &BaseExecutor[any]{}
 
+// RecordEvent records the event with the execution, and logs it to the policy's Logger, if any.
+func (e *BaseExecutor[R]) RecordEvent(exec ExecutionInternal[R], event failsafe.PolicyEvent) {
+	exec.RecordEvent(event)
+	if e.Logger != nil {
+		event.AttemptStats = exec.CopyWithResult(nil)
+		e.Logger.PolicyEvent(exec.Context(), event)
+	}
+}
+
 func (e *BaseExecutor[R]) PreExecute(_ ExecutionInternal[R]) *common.PolicyResult[R] {
 	return nil
 }
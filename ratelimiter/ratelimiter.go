@@ -3,6 +3,7 @@ package ratelimiter
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
@@ -111,6 +112,16 @@ type RateLimiter[R any] interface {
 	//  - Returns 0 if the permit was successfully reserved and no waiting is needed.
 	//  - Returns -1 if the permit was not reserved because the wait time would be greater than the maxWaitTime.
 	TryReservePermits(requestedPermits uint, maxWaitTime time.Duration) time.Duration
+
+	// Metrics returns metrics for the RateLimiter.
+	Metrics() Metrics
+}
+
+// Metrics contains metrics for a RateLimiter.
+type Metrics interface {
+	// WaitTime returns the time that an execution would currently need to wait for a permit, without acquiring one.
+	// Returns 0 if a permit is immediately available.
+	WaitTime() time.Duration
 }
 
 /*
@@ -129,6 +140,9 @@ type RateLimiterBuilder[R any] interface {
 	// OnRateLimitExceeded registers the listener to be called when the rate limit is exceeded.
 	OnRateLimitExceeded(listener func(failsafe.ExecutionEvent[R])) RateLimiterBuilder[R]
 
+	// WithLogger configures the logger to log the RateLimiter's events to. See failsafe.Logger.
+	WithLogger(logger *slog.Logger) RateLimiterBuilder[R]
+
 	// Build returns a new RateLimiter using the builder's configuration.
 	Build() RateLimiter[R]
 }
@@ -137,6 +151,7 @@ type rateLimiterConfig[R any] struct {
 	// Common
 	maxWaitTime         time.Duration
 	onRateLimitExceeded func(failsafe.ExecutionEvent[R])
+	logger              *failsafe.Logger
 
 	// Smooth
 	interval time.Duration
@@ -242,6 +257,11 @@ func (c *rateLimiterConfig[R]) OnRateLimitExceeded(listener func(event failsafe.
 	return c
 }
 
+func (c *rateLimiterConfig[R]) WithLogger(logger *slog.Logger) RateLimiterBuilder[R] {
+	c.logger = failsafe.NewLoggerBuilder(logger).Build()
+	return c
+}
+
 func (c *rateLimiterConfig[R]) Build() RateLimiter[R] {
 	if c.interval != 0 {
 		return &rateLimiter[R]{
@@ -346,10 +366,20 @@ func (r *rateLimiter[R]) TryReservePermits(requestedPermits uint, maxWaitTime ti
 	return r.stats.acquirePermits(int(requestedPermits), maxWaitTime)
 }
 
+func (r *rateLimiter[R]) Metrics() Metrics {
+	return r
+}
+
+func (r *rateLimiter[R]) WaitTime() time.Duration {
+	return r.stats.waitTime(1)
+}
+
 func (r *rateLimiter[R]) ToExecutor(_ R) any {
 	rle := &rateLimiterExecutor[R]{
-		BaseExecutor: &policy.BaseExecutor[R]{},
-		rateLimiter:  r,
+		BaseExecutor: &policy.BaseExecutor[R]{
+			Logger: r.config.logger,
+		},
+		rateLimiter: r,
 	}
 	rle.Executor = rle
 	return rle
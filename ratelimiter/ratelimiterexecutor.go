@@ -19,6 +19,7 @@ func (e *rateLimiterExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *comm
 	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
 		execInternal := exec.(policy.ExecutionInternal[R])
 		if err := e.acquirePermitsWithMaxWait(execInternal.Context(), exec, 1, e.config.maxWaitTime); err != nil {
+			e.RecordEvent(execInternal, failsafe.PolicyEvent{Type: failsafe.RateLimitExceeded, Policy: "RateLimiter", Source: e.rateLimiter, Error: err})
 			if e.config.onRateLimitExceeded != nil {
 				e.config.onRateLimitExceeded(failsafe.ExecutionEvent[R]{
 					ExecutionAttempt: execInternal,
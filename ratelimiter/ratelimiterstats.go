@@ -12,6 +12,9 @@ type rateLimiterStats interface {
 	// else returns -1 if the wait time would exceed the maxWaitTime. A maxWaitTime of -1 indicates no max wait.
 	acquirePermits(requestedPermits int, maxWaitTime time.Duration) time.Duration
 
+	// waitTime returns the time that would need to be waited in order to use requestedPermits, without acquiring them.
+	waitTime(requestedPermits int) time.Duration
+
 	reset()
 }
 
@@ -32,10 +35,27 @@ func (s *smoothRateLimiterStats[R]) acquirePermits(requestedPermits int, maxWait
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
+	newNextFreePermitTime, waitTime := s.computeWaitTime(requestedPermits)
+	if exceedsMaxWaitTime(waitTime, maxWaitTime) {
+		return -1
+	}
+
+	s.nextFreePermitTime = newNextFreePermitTime
+	return waitTime
+}
+
+func (s *smoothRateLimiterStats[R]) waitTime(requestedPermits int) time.Duration {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, waitTime := s.computeWaitTime(requestedPermits)
+	return waitTime
+}
+
+// computeWaitTime returns the next free permit time and wait time that would result from acquiring requestedPermits.
+// Must be called while holding the mtx.
+func (s *smoothRateLimiterStats[R]) computeWaitTime(requestedPermits int) (newNextFreePermitTime time.Duration, waitTime time.Duration) {
 	currentTime := s.stopwatch.ElapsedTime()
 	requestedPermitTime := s.config.interval * time.Duration(requestedPermits)
-	var waitTime time.Duration
-	var newNextFreePermitTime time.Duration
 
 	// If a permit is currently available
 	if currentTime >= s.nextFreePermitTime {
@@ -47,12 +67,7 @@ func (s *smoothRateLimiterStats[R]) acquirePermits(requestedPermits int, maxWait
 	}
 
 	waitTime = max(newNextFreePermitTime-currentTime-s.config.interval, time.Duration(0))
-	if exceedsMaxWaitTime(waitTime, maxWaitTime) {
-		return -1
-	}
-
-	s.nextFreePermitTime = newNextFreePermitTime
-	return waitTime
+	return newNextFreePermitTime, waitTime
 }
 
 func (s *smoothRateLimiterStats[R]) reset() {
@@ -81,6 +96,24 @@ func (s *burstyRateLimiterStats[R]) acquirePermits(requestedPermits int, maxWait
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
+	waitTime := s.computeWaitTime(requestedPermits)
+	if exceedsMaxWaitTime(waitTime, maxWaitTime) {
+		return -1
+	}
+
+	s.availablePermits -= requestedPermits
+	return waitTime
+}
+
+func (s *burstyRateLimiterStats[R]) waitTime(requestedPermits int) time.Duration {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.computeWaitTime(requestedPermits)
+}
+
+// computeWaitTime updates the current period and available permits, and returns the time that would need to be waited
+// in order to use requestedPermits. Must be called while holding the mtx.
+func (s *burstyRateLimiterStats[R]) computeWaitTime(requestedPermits int) time.Duration {
 	currentTime := s.stopwatch.ElapsedTime()
 	newCurrentPeriod := int(currentTime / s.config.period)
 
@@ -96,28 +129,22 @@ func (s *burstyRateLimiterStats[R]) acquirePermits(requestedPermits int, maxWait
 		}
 	}
 
-	waitTime := 0 * time.Second
-	if requestedPermits > s.availablePermits {
-		nextPeriodTime := time.Duration(s.currentPeriod+1) * s.config.period
-		timeToNextPeriod := nextPeriodTime - currentTime
-		permitDeficit := requestedPermits - s.availablePermits
-		additionalPeriods := permitDeficit / s.config.periodPermits
-		additionalUnits := permitDeficit % s.config.periodPermits
-
-		// Do not wait for an additional period if we're not using any permits from it
-		if additionalUnits == 0 {
-			additionalPeriods -= 1
-		}
-
-		// The time to wait until the beginning of the next period that will have free permits
-		waitTime = timeToNextPeriod + (time.Duration(additionalPeriods) * s.config.period)
-		if exceedsMaxWaitTime(waitTime, maxWaitTime) {
-			return -1
-		}
+	if requestedPermits <= s.availablePermits {
+		return 0
+	}
+	nextPeriodTime := time.Duration(s.currentPeriod+1) * s.config.period
+	timeToNextPeriod := nextPeriodTime - currentTime
+	permitDeficit := requestedPermits - s.availablePermits
+	additionalPeriods := permitDeficit / s.config.periodPermits
+	additionalUnits := permitDeficit % s.config.periodPermits
+
+	// Do not wait for an additional period if we're not using any permits from it
+	if additionalUnits == 0 {
+		additionalPeriods -= 1
 	}
 
-	s.availablePermits -= requestedPermits
-	return waitTime
+	// The time to wait until the beginning of the next period that will have free permits
+	return timeToNextPeriod + (time.Duration(additionalPeriods) * s.config.period)
 }
 
 func (s *burstyRateLimiterStats[R]) reset() {
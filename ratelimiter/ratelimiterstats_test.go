@@ -178,6 +178,25 @@ func TestShouldHaveZeroWaitTime(t *testing.T) {
 	})
 }
 
+// Asserts that waitTime returns the wait time that the next acquire would have, without acquiring permits.
+func TestWaitTime(t *testing.T) {
+	test := func(stats rateLimiterStats, stopwatch *testutil.TestStopwatch) {
+		assert.Equal(t, time.Duration(0), stats.waitTime(1))
+		acquireNTimes(stats, 1, 3)
+		stopwatch.CurrentTime = testutil.MillisToNanos(200)
+		waitTime := stats.waitTime(1)
+		assert.True(t, waitTime > 0)
+		assert.Equal(t, waitTime, stats.waitTime(1))
+		assert.Equal(t, waitTime, stats.acquirePermits(1, -1))
+	}
+
+	// Test for smooth stats
+	test(newSmoothLimiterStats(500 * time.Millisecond))
+
+	// Test for bursty stats
+	test(newBurstyLimiterStats(2, time.Second))
+}
+
 func newSmoothLimiterStats(maxRate time.Duration) (*smoothRateLimiterStats[any], *testutil.TestStopwatch) {
 	stats := SmoothBuilderWithMaxRate[any](maxRate).Build().(*rateLimiter[any]).stats.(*smoothRateLimiterStats[any])
 	stopwatch := &testutil.TestStopwatch{}
@@ -27,6 +27,10 @@ type ExecutionResult[R any] interface {
 	// Error returns the execution error else nil, blocking until the execution is done.
 	Error() error
 
+	// Target returns the target of the execution attempt that produced the result, when targets are configured, else an
+	// empty string. Blocks until the execution is done.
+	Target() string
+
 	// Cancel cancels the execution if it is not already done, with ErrExecutionCanceled as the error. If a Context was
 	// configured with the execution, a child context will be created for the execution and canceled as well.
 	Cancel()
@@ -73,6 +77,14 @@ func (e *executionResult[R]) Error() error {
 	return err
 }
 
+func (e *executionResult[R]) Target() string {
+	<-e.doneChan
+	if result := e.result.Load(); result != nil && *result != nil {
+		return (*result).Target
+	}
+	return ""
+}
+
 func (e *executionResult[R]) Cancel() {
 	// Propagate cancelation to contexts
 	e.execution.Cancel(&common.PolicyResult[R]{
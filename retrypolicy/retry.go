@@ -2,9 +2,11 @@ package retrypolicy
 
 import (
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
@@ -88,6 +90,11 @@ type RetryPolicyBuilder[R any] interface {
 	// AbortIf specifies that retries should be aborted if the predicate matches the result or error.
 	AbortIf(predicate func(R, error) bool) RetryPolicyBuilder[R]
 
+	// AbortIfExecution specifies that retries should be aborted if the predicate matches the execution, whose LastResult
+	// and LastError are the result and error of the failed attempt. This allows retries to be aborted based on the
+	// execution's Context, such as when a result or error alone does not indicate whether a retry is safe.
+	AbortIfExecution(predicate func(exec failsafe.Execution[R]) bool) RetryPolicyBuilder[R]
+
 	// ReturnLastFailure configures the policy to return the last failure result or error after attempts are exceeded,
 	// rather than returning ExceededError.
 	ReturnLastFailure() RetryPolicyBuilder[R]
@@ -147,6 +154,9 @@ type RetryPolicyBuilder[R any] interface {
 	// duration are exceeded. The provided event will contain the last execution result and error.
 	OnRetriesExceeded(listener func(failsafe.ExecutionEvent[R])) RetryPolicyBuilder[R]
 
+	// WithLogger configures the logger to log the RetryPolicy's events to. See failsafe.Logger.
+	WithLogger(logger *slog.Logger) RetryPolicyBuilder[R]
+
 	// Build returns a new RetryPolicy using the builder's configuration.
 	Build() RetryPolicy[R]
 }
@@ -155,6 +165,7 @@ type retryPolicyConfig[R any] struct {
 	*policy.BaseFailurePolicy[R]
 	*policy.BaseDelayablePolicy[R]
 	*policy.BaseAbortablePolicy[R]
+	abortExecutionConditions []func(failsafe.Execution[R]) bool
 
 	returnLastFailure bool
 	delayMin          time.Duration
@@ -170,6 +181,7 @@ type retryPolicyConfig[R any] struct {
 	onRetry           func(failsafe.ExecutionEvent[R])
 	onRetryScheduled  func(failsafe.ExecutionScheduledEvent[R])
 	onRetriesExceeded func(failsafe.ExecutionEvent[R])
+	logger            *failsafe.Logger
 }
 
 var _ RetryPolicyBuilder[any] = &retryPolicyConfig[any]{}
@@ -195,6 +207,11 @@ func Builder[R any]() RetryPolicyBuilder[R] {
 	}
 }
 
+func (c *retryPolicyConfig[R]) WithLogger(logger *slog.Logger) RetryPolicyBuilder[R] {
+	c.logger = failsafe.NewLoggerBuilder(logger).Build()
+	return c
+}
+
 func (c *retryPolicyConfig[R]) Build() RetryPolicy[R] {
 	rpCopy := *c
 	return &retryPolicy[R]{
@@ -217,6 +234,11 @@ func (c *retryPolicyConfig[R]) AbortIf(predicate func(R, error) bool) RetryPolic
 	return c
 }
 
+func (c *retryPolicyConfig[R]) AbortIfExecution(predicate func(exec failsafe.Execution[R]) bool) RetryPolicyBuilder[R] {
+	c.abortExecutionConditions = append(c.abortExecutionConditions, predicate)
+	return c
+}
+
 func (c *retryPolicyConfig[R]) HandleErrors(errs ...error) RetryPolicyBuilder[R] {
 	c.BaseFailurePolicy.HandleErrors(errs...)
 	return c
@@ -331,6 +353,23 @@ func (c *retryPolicyConfig[R]) OnRetriesExceeded(listener func(failsafe.Executio
 	return c
 }
 
+// isAbortable returns whether retries should be aborted for the result of the exec.
+func (c *retryPolicyConfig[R]) isAbortable(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) bool {
+	if c.IsAbortable(result.Result, result.Error) {
+		return true
+	}
+	if len(c.abortExecutionConditions) == 0 {
+		return false
+	}
+	execWithResult := exec.CopyWithResult(result)
+	for _, predicate := range c.abortExecutionConditions {
+		if predicate(execWithResult) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *retryPolicyConfig[R]) allowsRetries() bool {
 	return c.maxRetries == -1 || c.maxRetries > 0
 }
@@ -339,6 +378,7 @@ func (rp *retryPolicy[R]) ToExecutor(_ R) any {
 	rpe := &retryPolicyExecutor[R]{
 		BaseExecutor: &policy.BaseExecutor[R]{
 			BaseFailurePolicy: rp.config.BaseFailurePolicy,
+			Logger:            rp.config.logger,
 		},
 		retryPolicy: rp,
 	}
@@ -49,6 +49,13 @@ func (e *retryPolicyExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *comm
 
 			// Delay
 			delay := e.getDelay(exec)
+			e.RecordEvent(execInternal, failsafe.PolicyEvent{
+				Type:   failsafe.RetryScheduled,
+				Policy: "RetryPolicy",
+				Source: e.retryPolicy,
+				Delay:  delay,
+				Error:  result.Error,
+			})
 			if e.config.onRetryScheduled != nil {
 				e.config.onRetryScheduled(failsafe.ExecutionScheduledEvent[R]{
 					ExecutionAttempt: execInternal.CopyWithResult(result),
@@ -83,17 +90,23 @@ func (e *retryPolicyExecutor[R]) OnFailure(exec policy.ExecutionInternal[R], res
 	maxRetriesExceeded := e.config.maxRetries != -1 && e.failedAttempts > e.config.maxRetries
 	maxDurationExceeded := e.config.maxDuration != 0 && exec.ElapsedTime() > e.config.maxDuration
 	e.retriesExceeded = maxRetriesExceeded || maxDurationExceeded
-	isAbortable := e.config.IsAbortable(result.Result, result.Error)
+	isAbortable := e.config.isAbortable(exec, result)
 	shouldRetry := !isAbortable && !e.retriesExceeded && e.config.allowsRetries()
 	done := isAbortable || !shouldRetry
 
 	// Call listeners
-	if isAbortable && e.config.onAbort != nil {
-		e.config.onAbort(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+	if isAbortable {
+		e.RecordEvent(exec, failsafe.PolicyEvent{Type: failsafe.RetryAborted, Policy: "RetryPolicy", Source: e.retryPolicy, Error: result.Error})
+		if e.config.onAbort != nil {
+			e.config.onAbort(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+		}
 	}
 	if e.retriesExceeded {
-		if !isAbortable && e.config.onRetriesExceeded != nil {
-			e.config.onRetriesExceeded(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+		if !isAbortable {
+			e.RecordEvent(exec, failsafe.PolicyEvent{Type: failsafe.RetriesExceeded, Policy: "RetryPolicy", Source: e.retryPolicy, Error: result.Error})
+			if e.config.onRetriesExceeded != nil {
+				e.config.onRetriesExceeded(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+			}
 		}
 		if !e.config.returnLastFailure {
 			return internal.FailureResult[R](&ExceededError{
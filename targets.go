@@ -0,0 +1,30 @@
+package failsafe
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// TargetPicker returns the target that an execution attempt should be performed against, such as an endpoint or
+// replica. The avoid targets have already been attempted by the same execution, either because they failed or because
+// they are still in progress, such as with a hedge, and should be avoided when possible.
+type TargetPicker func(stats ExecutionStats, avoid []string) string
+
+// roundRobin returns a TargetPicker that rotates through the targets, skipping any targets that should be avoided. If all
+// targets should be avoided, the next target in the rotation is returned.
+func roundRobin(targets []string) TargetPicker {
+	var next atomic.Uint64
+	return func(_ ExecutionStats, avoid []string) string {
+		if len(targets) == 0 {
+			return ""
+		}
+		start := int((next.Add(1) - 1) % uint64(len(targets)))
+		for i := range targets {
+			target := targets[(start+i)%len(targets)]
+			if !slices.Contains(avoid, target) {
+				return target
+			}
+		}
+		return targets[start]
+	}
+}
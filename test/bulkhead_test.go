@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/bulkhead"
 	"github.com/failsafe-go/failsafe-go/internal/policytesting"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
@@ -61,3 +62,34 @@ func TestBulkheadMaxWaitTimeExceeded(t *testing.T) {
 		Run(testutil.RunFn(nil)).
 		AssertFailure(1, 0, bulkhead.ErrFull)
 }
+
+// Asserts that permits are released after executions, including failed executions.
+func TestBulkheadPermitReleasedAfterExecution(t *testing.T) {
+	// Given
+	bh := bulkhead.With[any](1)
+	executor := failsafe.NewExecutor[any](bh)
+
+	// When
+	err1 := executor.RunWithExecution(testutil.RunFn(nil))
+	err2 := executor.RunWithExecution(testutil.RunFn(testutil.ErrInvalidState))
+	err3 := executor.RunWithExecution(testutil.RunFn(nil))
+
+	// Then
+	assert.NoError(t, err1)
+	assert.ErrorIs(t, err2, testutil.ErrInvalidState)
+	assert.NoError(t, err3)
+	assert.True(t, bh.TryAcquirePermit())
+}
+
+// Asserts that a Bulkhead without a maxWaitTime permits executions while it has permits available.
+func TestBulkheadWithoutMaxWaitTime(t *testing.T) {
+	// Given
+	bh := bulkhead.With[any](2)
+	bh.TryAcquirePermit()
+
+	// When / Then
+	testutil.Test[any](t).
+		With(bh).
+		Run(testutil.RunFn(nil)).
+		AssertSuccess(1, 1, nil)
+}
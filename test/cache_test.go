@@ -168,3 +168,41 @@ func TestDoNotCacheOnError(t *testing.T) {
 			assert.Equal(t, 1, stats.CacheMisses())
 		})
 }
+
+// Tests caching with keys from a key func, a typed context key, and a namespace and version.
+func TestCacheWithKeyFunc(t *testing.T) {
+	// Given
+	cache, failsafeCache := policytesting.NewCache[string]()
+	cp := cachepolicy.Builder[string](failsafeCache).
+		WithKeyFunc(func(exec failsafe.Execution[string]) (string, bool) {
+			tenant, ok := exec.Context().Value(tenantKey).(string)
+			return cachepolicy.HashKey(tenant, 1), ok
+		}).
+		WithNamespace("users").
+		WithVersion("v2").
+		Build()
+	executor := failsafe.NewExecutor[string](cp)
+
+	// When / Then
+	executor.WithContext(context.WithValue(context.Background(), tenantKey, "foo")).GetWithExecution(testutil.GetFn("bar", nil))
+	assert.Equal(t, "bar", cache["users:v2:"+cachepolicy.HashKey("foo", 1)])
+
+	// Key func does not cache
+	executor.GetWithExecution(testutil.GetFn("baz", nil))
+	assert.Len(t, cache, 1)
+
+	// Typed context key
+	ctx := context.WithValue(context.Background(), cachepolicy.CacheKey, testKeyer("qux"))
+	executor.WithContext(ctx).GetWithExecution(testutil.GetFn("bar", nil))
+	assert.Equal(t, "bar", cache["users:v2:qux"])
+}
+
+type cacheTestKey int
+
+const tenantKey cacheTestKey = 0
+
+type testKeyer string
+
+func (k testKeyer) CacheKey() string {
+	return string(k)
+}
@@ -0,0 +1,151 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/chaos"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// Tests that injected errors are handled by outer policies.
+func TestChaosInjectError(t *testing.T) {
+	// Given
+	var events []chaos.FaultInjectedEvent[bool]
+	c := chaos.Builder[bool]().
+		InjectError(1, chaos.ErrInjected).
+		OnFaultInjected(func(e chaos.FaultInjectedEvent[bool]) {
+			events = append(events, e)
+		}).
+		Build()
+	rp := retrypolicy.WithDefaults[bool]()
+
+	// When / Then
+	testutil.Test[bool](t).
+		With(rp, c).
+		Setup(func() {
+			events = nil
+		}).
+		Get(testutil.GetFn(true, nil)).
+		AssertFailure(3, 0, chaos.ErrInjected, func() {
+			assert.Len(t, events, 3)
+			assert.Equal(t, chaos.ErrorFault, events[0].Type)
+			assert.Equal(t, chaos.ErrInjected, events[0].Error)
+		})
+}
+
+func TestChaosInjectResult(t *testing.T) {
+	c := chaos.Builder[bool]().
+		InjectResult(1, false).
+		Build()
+
+	testutil.Test[bool](t).
+		With(c).
+		Get(testutil.GetFn(true, nil)).
+		AssertSuccess(1, 0, false)
+}
+
+func TestChaosInjectPanic(t *testing.T) {
+	c := chaos.Builder[bool]().
+		InjectPanic(1, "test").
+		Build()
+
+	assert.PanicsWithValue(t, "test", func() {
+		failsafe.Get(func() (bool, error) { return true, nil }, c)
+	})
+}
+
+// Tests that injected latency is added before the execution is performed.
+func TestChaosInjectLatency(t *testing.T) {
+	// Given
+	var latency time.Duration
+	c := chaos.Builder[bool]().
+		InjectLatency(1, 50*time.Millisecond).
+		OnFaultInjected(func(e chaos.FaultInjectedEvent[bool]) {
+			latency = e.Latency
+		}).
+		Build()
+
+	// When
+	elapsed := testutil.Timed(func() {
+		result, err := failsafe.Get(func() (bool, error) { return true, nil }, c)
+		assert.True(t, result)
+		assert.Nil(t, err)
+	})
+
+	// Then
+	assert.Equal(t, 50*time.Millisecond, latency)
+	assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond)
+}
+
+// Tests that injected latency is interrupted when an execution is canceled.
+func TestChaosInjectLatencyWithTimeout(t *testing.T) {
+	c := chaos.Builder[bool]().
+		InjectRandomLatency(1, time.Second, 2*time.Second).
+		Build()
+	to := timeout.With[bool](50 * time.Millisecond)
+
+	testutil.Test[bool](t).
+		With(to, c).
+		Get(testutil.GetFn(true, nil)).
+		AssertFailure(1, 0, timeout.ErrExceeded)
+}
+
+func TestChaosShouldNotInjectWithZeroProbability(t *testing.T) {
+	c := chaos.Builder[bool]().
+		InjectError(0, chaos.ErrInjected).
+		Build()
+
+	testutil.Test[bool](t).
+		With(c).
+		Get(testutil.GetFn(true, nil)).
+		AssertSuccess(1, 1, true)
+}
+
+func TestChaosEnableAndDisable(t *testing.T) {
+	// Given
+	c := chaos.Builder[bool]().
+		InjectError(1, chaos.ErrInjected).
+		WithEnabled(false).
+		Build()
+	fn := func() (bool, error) { return true, nil }
+
+	// When / Then
+	_, err := failsafe.Get(fn, c)
+	assert.Nil(t, err)
+	assert.False(t, c.IsEnabled())
+
+	c.Enable()
+	_, err = failsafe.Get(fn, c)
+	assert.ErrorIs(t, err, chaos.ErrInjected)
+
+	c.Disable()
+	_, err = failsafe.Get(fn, c)
+	assert.Nil(t, err)
+}
+
+func TestChaosWithScopes(t *testing.T) {
+	// Given
+	c := chaos.Builder[bool]().
+		InjectError(1, chaos.ErrInjected).
+		WithScopes("staging").
+		Build()
+	fn := func() (bool, error) { return true, nil }
+	executor := failsafe.NewExecutor[bool](c)
+
+	// When / Then
+	_, err := executor.Get(fn)
+	assert.Nil(t, err)
+
+	_, err = executor.WithContext(context.WithValue(context.Background(), chaos.ScopeKey, "prod")).Get(fn)
+	assert.Nil(t, err)
+
+	_, err = executor.WithContext(context.WithValue(context.Background(), chaos.ScopeKey, "staging")).Get(fn)
+	assert.ErrorIs(t, err, chaos.ErrInjected)
+}
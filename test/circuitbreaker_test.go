@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
 	"github.com/failsafe-go/failsafe-go/internal/policytesting"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
 	"github.com/failsafe-go/failsafe-go/retrypolicy"
@@ -203,3 +205,48 @@ func TestShouldSupportTimeBasedFailureRateThresholding(t *testing.T) {
 	executor.Get(testutil.GetTrueFn)
 	assert.True(t, cb.IsClosed())
 }
+
+// Asserts that hedges that lose to another attempt are not recorded.
+func TestCircuitBreakerShouldNotRecordCanceledHedges(t *testing.T) {
+	// Given
+	cb := circuitbreaker.WithDefaults[bool]()
+	hp := hedgepolicy.WithDelay[bool](10 * time.Millisecond)
+
+	// When
+	result, err := failsafe.NewExecutor[bool](hp, cb).GetWithExecution(func(exec failsafe.Execution[bool]) (bool, error) {
+		if exec.Attempts() == 1 {
+			<-exec.Canceled()
+			return false, exec.Context().Err()
+		}
+		return true, nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, result)
+	assert.Eventually(t, func() bool {
+		return cb.Metrics().Executions() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Never(t, func() bool {
+		return cb.Metrics().Executions() != 1
+	}, 50*time.Millisecond, 10*time.Millisecond)
+	assert.True(t, cb.IsClosed())
+}
+
+// Asserts that executions whose Context is canceled by the caller are still recorded.
+func TestCircuitBreakerShouldRecordCanceledExecutions(t *testing.T) {
+	// Given
+	cb := circuitbreaker.WithDefaults[any]()
+	cb.HalfOpen()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// When
+	err := failsafe.NewExecutor[any](cb).WithContext(ctx).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		cancel()
+		return exec.Context().Err()
+	})
+
+	// Then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, cb.IsOpen())
+}
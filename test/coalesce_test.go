@@ -0,0 +1,242 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/coalesce"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+// Tests that concurrent executions with the same key share a single execution and its result.
+func TestCoalesce(t *testing.T) {
+	// Given
+	var coalescedEvent coalesce.CoalescedEvent[string]
+	c := coalesce.Builder[string]().
+		WithKey("foo").
+		OnCoalesced(func(e coalesce.CoalescedEvent[string]) {
+			coalescedEvent = e
+		}).
+		Build()
+	executions := atomic.Int32{}
+	release := make(chan struct{})
+	fn := func() (string, error) {
+		executions.Add(1)
+		<-release
+		return "bar", nil
+	}
+
+	// When
+	results := getConcurrently(t, 5, c, fn, release)
+
+	// Then
+	assert.Equal(t, int32(1), executions.Load())
+	for _, result := range results {
+		assert.Equal(t, "bar", result.result)
+		assert.Nil(t, result.err)
+	}
+	assert.Equal(t, 4, coalescedEvent.Joined)
+	assert.Equal(t, "foo", coalescedEvent.Key)
+	assert.Equal(t, "bar", coalescedEvent.Result)
+}
+
+// Tests that the context of a shared execution is canceled once it's done.
+func TestCoalesceShouldCancelSharedContext(t *testing.T) {
+	// Given
+	c := coalesce.Builder[string]().WithKey("foo").Build()
+	var sharedCtx context.Context
+
+	// When
+	result, err := failsafe.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		sharedCtx = exec.Context()
+		return "bar", nil
+	}, c)
+
+	// Then
+	assert.Equal(t, "bar", result)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return sharedCtx.Err() != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+// Tests that executions with different keys, or no key, are not coalesced.
+func TestCoalesceWithDifferentKeys(t *testing.T) {
+	// Given
+	c := coalesce.Builder[string]().
+		WithKeyFunc(func(exec failsafe.Execution[string]) string {
+			if key, ok := exec.Context().Value(testKey).(string); ok {
+				return key
+			}
+			return ""
+		}).
+		Build()
+	executions := atomic.Int32{}
+	release := make(chan struct{})
+	started := sync.WaitGroup{}
+	started.Add(3)
+	fn := func() (string, error) {
+		executions.Add(1)
+		started.Done()
+		<-release
+		return "bar", nil
+	}
+
+	// When
+	wg := sync.WaitGroup{}
+	for _, ctx := range []context.Context{
+		context.WithValue(context.Background(), testKey, "foo"),
+		context.WithValue(context.Background(), testKey, "baz"),
+		context.Background(),
+	} {
+		wg.Add(1)
+		go func(ctx context.Context) {
+			defer wg.Done()
+			failsafe.NewExecutor[string](c).WithContext(ctx).Get(fn)
+		}(ctx)
+	}
+	started.Wait()
+	close(release)
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(3), executions.Load())
+}
+
+// Tests that joined callers perform another execution when errors are not shared.
+func TestCoalesceWithoutSharedErrors(t *testing.T) {
+	// Given
+	c := coalesce.Builder[string]().
+		WithKey("foo").
+		ShareErrors(false).
+		Build()
+	executions := atomic.Int32{}
+	release := make(chan struct{})
+	fn := func() (string, error) {
+		if executions.Add(1) == 1 {
+			<-release
+			return "", testutil.ErrInvalidState
+		}
+		time.Sleep(50 * time.Millisecond) // Wait for the other caller to join
+		return "bar", nil
+	}
+
+	// When
+	results := getConcurrently(t, 3, c, fn, release)
+
+	// Then
+	errs := 0
+	for _, result := range results {
+		if result.err != nil {
+			errs++
+			assert.ErrorIs(t, result.err, testutil.ErrInvalidState)
+		} else {
+			assert.Equal(t, "bar", result.result)
+		}
+	}
+	assert.Equal(t, 1, errs)
+	assert.Equal(t, int32(2), executions.Load())
+}
+
+// Tests that canceling the caller that started a shared execution does not cancel it for joined callers.
+func TestCoalesceWithCanceledLeader(t *testing.T) {
+	// Given
+	c := coalesce.Builder[string]().WithKey("foo").Build()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	fn := func(exec failsafe.Execution[string]) (string, error) {
+		close(started)
+		select {
+		case <-release:
+			return "bar", nil
+		case <-exec.Canceled():
+			return "", exec.Context().Err()
+		}
+	}
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := failsafe.NewExecutor[string](c).WithContext(leaderCtx).GetWithExecution(fn)
+		leaderErr <- err
+	}()
+	<-started
+	followerResult := make(chan string, 1)
+	go func() {
+		result, _ := failsafe.NewExecutor[string](c).GetWithExecution(fn)
+		followerResult <- result
+	}()
+	time.Sleep(50 * time.Millisecond) // Wait for the follower to join
+
+	// When
+	cancelLeader()
+
+	// Then
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+	close(release)
+	assert.Equal(t, "bar", <-followerResult)
+}
+
+// Tests that canceling all callers cancels the shared execution.
+func TestCoalesceWithAllCallersCanceled(t *testing.T) {
+	// Given
+	c := coalesce.Builder[string]().WithKey("foo").Build()
+	started := make(chan struct{})
+	sharedCanceled := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	resultErr := make(chan error, 1)
+	go func() {
+		_, err := failsafe.NewExecutor[string](c).WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+			close(started)
+			<-exec.Canceled()
+			close(sharedCanceled)
+			return "", exec.Context().Err()
+		})
+		resultErr <- err
+	}()
+	<-started
+
+	// When
+	cancel()
+
+	// Then
+	assert.ErrorIs(t, <-resultErr, context.Canceled)
+	<-sharedCanceled
+
+	// A new execution should not join the canceled one
+	result, err := failsafe.Get(func() (string, error) { return "bar", nil }, c)
+	assert.Equal(t, "bar", result)
+	assert.Nil(t, err)
+}
+
+type coalesceTestKey int
+
+const testKey coalesceTestKey = 0
+
+type coalesceResult struct {
+	result string
+	err    error
+}
+
+// getConcurrently performs count concurrent executions of fn with c, closing release once the executions have joined.
+func getConcurrently(t *testing.T, count int, c coalesce.Coalescer[string], fn func() (string, error), release chan struct{}) []coalesceResult {
+	results := make([]coalesceResult, count)
+	wg := sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := failsafe.Get(fn, c)
+			results[i] = coalesceResult{result, err}
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond) // Wait for the executions to join
+	close(release)
+	wg.Wait()
+	return results
+}
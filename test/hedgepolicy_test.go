@@ -1,6 +1,7 @@
 package test
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -140,3 +141,215 @@ func TestCancelOnResult(t *testing.T) {
 			})
 	})
 }
+
+// Asserts that a percentile based hedge delay uses the max delay until enough attempts are recorded, and then hedges
+// attempts that are slower than the percentile of recent attempts.
+func TestHedgeWithPercentile(t *testing.T) {
+	// Given
+	stats := &policytesting.Stats{}
+	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithPercentile[int](90, 10*time.Millisecond, time.Second), stats).Build()
+	slowFn := func(exec failsafe.Execution[int]) (int, error) {
+		if exec.IsHedge() {
+			return 2, nil
+		}
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-exec.Canceled():
+		}
+		return 1, nil
+	}
+
+	// When / Then
+	testutil.Test[int](t).
+		With(hp).
+		Reset(stats).
+		Get(slowFn).
+		AssertSuccess(1, 1, 1, func() {
+			assert.Equal(t, 0, stats.Hedges())
+		})
+
+	// Given recent fast attempts
+	for i := 0; i < 20; i++ {
+		result, err := failsafe.Get[int](func() (int, error) {
+			return 0, nil
+		}, hp)
+		assert.Equal(t, 0, result)
+		assert.Nil(t, err)
+	}
+
+	// When / Then
+	elapsed := testutil.Timed(func() {
+		testutil.Test[int](t).
+			With(hp).
+			Reset(stats).
+			Get(slowFn).
+			AssertSuccess(2, -1, 2, func() {
+				assert.Equal(t, 1, stats.Hedges())
+			})
+	})
+	assert.Less(t, elapsed, 400*time.Millisecond)
+}
+
+// Asserts that hedges are skipped when they would exceed the hedge budget.
+func TestHedgeBudgetExceeded(t *testing.T) {
+	// Given
+	stats := &policytesting.Stats{}
+	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[int](10*time.Millisecond).
+		WithBudget(25, time.Minute), stats).
+		Build()
+	fn := func(exec failsafe.Execution[int]) (int, error) {
+		if !exec.IsHedge() {
+			time.Sleep(50 * time.Millisecond)
+		}
+		return exec.Attempts(), nil
+	}
+	for i := 0; i < 3; i++ {
+		failsafe.Get(func() (int, error) {
+			return 0, nil
+		}, hp)
+	}
+
+	// When 25% of 4 executions allows a hedge
+	result, err := failsafe.GetWithExecution(fn, hp)
+
+	// Then
+	assert.Equal(t, 2, result)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Hedges())
+	assert.Equal(t, 0, stats.HedgesSkipped())
+
+	// When 25% of 5 executions does not allow a second hedge
+	result, err = failsafe.GetWithExecution(fn, hp)
+
+	// Then
+	assert.Equal(t, 1, result)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Hedges())
+	assert.Equal(t, 1, stats.HedgesSkipped())
+	metrics := hp.Metrics()
+	assert.Equal(t, uint(5), metrics.Executions())
+	assert.Equal(t, uint(1), metrics.Hedges())
+	assert.Equal(t, uint(1), metrics.SkippedHedges())
+}
+
+// Asserts that a non-cancellable result is returned when a hedge is skipped after the outstanding attempt completes.
+func TestHedgeBudgetExceededWithCancelOnResult(t *testing.T) {
+	// Given
+	stats := &policytesting.Stats{}
+	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[int](10*time.Millisecond).
+		CancelOnResult(2).
+		WithBudget(10, time.Minute), stats).
+		Build()
+
+	// When / Then
+	testutil.Test[int](t).
+		With(hp).
+		Reset(stats).
+		Get(func(exec failsafe.Execution[int]) (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 1, nil
+		}).
+		AssertSuccess(1, 1, 1, func() {
+			assert.Equal(t, 0, stats.Hedges())
+			assert.Equal(t, 1, stats.HedgesSkipped())
+		})
+}
+
+// Asserts that hedges are performed against different targets, and that the target of the winning attempt is recorded.
+func TestHedgeWithTargets(t *testing.T) {
+	// Given
+	hp := hedgepolicy.BuilderWithDelay[string](10 * time.Millisecond).WithMaxHedges(2).Build()
+	var doneEvent failsafe.ExecutionDoneEvent[string]
+	executor := failsafe.NewExecutor[string](hp).
+		WithTargets("a", "b", "c").
+		OnDone(func(e failsafe.ExecutionDoneEvent[string]) {
+			doneEvent = e
+		})
+	var targets sync.Map
+
+	// When
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		targets.Store(exec.Attempts(), exec.Target())
+		if exec.Attempts() < 3 {
+			testutil.WaitAndAssertCanceled(t, time.Second, exec)
+		}
+		return exec.Target(), nil
+	})
+
+	// Then
+	var actualTargets []string
+	targets.Range(func(_, target any) bool {
+		actualTargets = append(actualTargets, target.(string))
+		return true
+	})
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, actualTargets)
+	winner, _ := targets.Load(3)
+	assert.Equal(t, winner, result)
+	assert.Nil(t, err)
+	assert.Equal(t, winner, doneEvent.Target)
+}
+
+// Asserts that results from attempts that lose to another attempt are discarded.
+func TestHedgeOnDiscarded(t *testing.T) {
+	// Given
+	var discarded sync.Map
+	hp := hedgepolicy.BuilderWithDelay[int](10 * time.Millisecond).
+		WithMaxHedges(2).
+		CancelOnResult(3).
+		OnDiscarded(func(result int, err error) {
+			discarded.Store(result, err)
+		}).
+		Build()
+
+	// When
+	result, err := failsafe.GetWithExecution(func(exec failsafe.Execution[int]) (int, error) {
+		attempt := exec.Attempts()
+		if attempt == 1 {
+			return attempt, testutil.ErrInvalidState
+		}
+		if attempt == 2 {
+			testutil.WaitAndAssertCanceled(t, time.Second, exec)
+		}
+		return attempt, nil
+	}, hp)
+
+	// Then
+	assert.Equal(t, 3, result)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		err1, ok1 := discarded.Load(1)
+		_, ok2 := discarded.Load(2)
+		return ok1 && ok2 && err1 == testutil.ErrInvalidState
+	}, time.Second, 10*time.Millisecond)
+	_, ok := discarded.Load(3)
+	assert.False(t, ok)
+}
+
+// Asserts that attempts that fail start the next hedge immediately when HedgeOnFailure is configured.
+func TestHedgeOnFailure(t *testing.T) {
+	// Given
+	hp := hedgepolicy.BuilderWithDelay[bool](time.Second).
+		WithMaxHedges(2).
+		CancelIf(func(_ bool, err error) bool {
+			return err == nil
+		}).
+		HedgeOnFailure().
+		Build()
+	var result bool
+	var err error
+
+	// When
+	elapsed := testutil.Timed(func() {
+		result, err = failsafe.NewExecutor[bool](hp).GetWithExecution(func(exec failsafe.Execution[bool]) (bool, error) {
+			if exec.Attempts() < 3 {
+				return false, testutil.ErrInvalidState
+			}
+			return true, nil
+		})
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, result)
+	assert.Less(t, elapsed, 500*time.Millisecond)
+}
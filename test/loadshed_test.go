@@ -0,0 +1,50 @@
+package test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/loadshed"
+)
+
+// Tests a LoadShedder composed outside a Bulkhead, where executions that are admitted by the LoadShedder wait for
+// bulkhead permits, and executions beyond the in-flight limit are shed.
+func TestLoadShedderWithBulkhead(t *testing.T) {
+	// Given
+	shedder := loadshed.Builder[any](loadshed.InFlightSignal(2)).
+		WithThreshold(loadshed.MediumPriority, 1).
+		Build()
+	bh := bulkhead.Builder[any](1).WithMaxWaitTime(time.Second).Build()
+	executor := failsafe.NewExecutor[any](shedder, bh)
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	fn := func() error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	// When
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, executor.Run(fn))
+		}()
+	}
+	<-started
+	assert.Eventually(t, func() bool { return shedder.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Then
+	assert.ErrorIs(t, executor.Run(fn), loadshed.ErrShed)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, 0.0, shedder.Load())
+	assert.Nil(t, executor.Run(fn))
+}
@@ -0,0 +1,227 @@
+package test
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+// Tests that retry events are logged with the execution's ID and attempt counters.
+func TestLoggerWithRetries(t *testing.T) {
+	// Given
+	rp := retrypolicy.Builder[bool]().WithMaxRetries(1).WithDelay(10 * time.Millisecond).Build()
+	handler := &recordingHandler{level: slog.LevelDebug}
+	var ids []string
+
+	// When
+	_, err := failsafe.NewExecutor[bool](rp).WithLogger(slog.New(handler)).GetWithExecution(func(exec failsafe.Execution[bool]) (bool, error) {
+		ids = append(ids, exec.ID())
+		return false, testutil.ErrInvalidState
+	})
+
+	// Then
+	assert.ErrorIs(t, err, retrypolicy.ErrExceeded)
+	assert.Len(t, ids, 2)
+	assert.Len(t, ids[0], 16)
+	assert.Equal(t, ids[0], ids[1])
+	records := handler.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "retry scheduled", records[0].Message)
+	assert.Equal(t, slog.LevelInfo, records[0].Level)
+	assert.Equal(t, map[string]any{
+		"execution_id": ids[0],
+		"policy":       "RetryPolicy",
+		"attempts":     int64(1),
+		"executions":   int64(1),
+		"retries":      int64(0),
+		"hedges":       int64(0),
+		"delay":        10 * time.Millisecond,
+		"error":        testutil.ErrInvalidState,
+	}, attrs(records[0]))
+	assert.Equal(t, "retries exceeded", records[1].Message)
+	assert.Equal(t, slog.LevelWarn, records[1].Level)
+	assert.Equal(t, ids[0], attrs(records[1])["execution_id"])
+	assert.Equal(t, int64(2), attrs(records[1])["attempts"])
+	assert.Equal(t, int64(1), attrs(records[1])["retries"])
+}
+
+// Tests that executions have different IDs.
+func TestExecutionIDs(t *testing.T) {
+	// Given
+	executor := failsafe.NewExecutor[any](retrypolicy.WithDefaults[any]())
+	var ids []string
+	fn := func(exec failsafe.Execution[any]) error {
+		ids = append(ids, exec.ID())
+		return nil
+	}
+
+	// When
+	executor.RunWithExecution(fn)
+	executor.RunWithExecution(fn)
+
+	// Then
+	assert.Len(t, ids, 2)
+	assert.NotEqual(t, ids[0], ids[1])
+}
+
+func TestLoggerWithLevel(t *testing.T) {
+	// Given
+	rp := retrypolicy.Builder[any]().WithMaxRetries(1).Build()
+	handler := &recordingHandler{level: slog.LevelInfo}
+	logger := failsafe.NewLoggerBuilder(slog.New(handler)).
+		WithLevel(failsafe.RetryScheduled, slog.LevelDebug).
+		WithLevel(failsafe.RetriesExceeded, slog.LevelError).
+		Build()
+
+	// When
+	failsafe.NewExecutor[any](rp).WithObserver(logger).RunWithExecution(testutil.RunFn(testutil.ErrInvalidState))
+
+	// Then
+	records := handler.Records()
+	assert.Len(t, records, 1)
+	assert.Equal(t, "retries exceeded", records[0].Message)
+	assert.Equal(t, slog.LevelError, records[0].Level)
+}
+
+func TestLoggerWithSampleRate(t *testing.T) {
+	// Given
+	rp := retrypolicy.Builder[any]().WithMaxRetries(1).Build()
+	unsampledHandler := &recordingHandler{level: slog.LevelDebug}
+	unsampled := failsafe.NewLoggerBuilder(slog.New(unsampledHandler)).WithSampleRate(0).Build()
+	halfSampledHandler1 := &recordingHandler{level: slog.LevelDebug}
+	halfSampled1 := failsafe.NewLoggerBuilder(slog.New(halfSampledHandler1)).WithSampleRate(.5).Build()
+	halfSampledHandler2 := &recordingHandler{level: slog.LevelDebug}
+	halfSampled2 := failsafe.NewLoggerBuilder(slog.New(halfSampledHandler2)).WithSampleRate(.5).Build()
+	executor := failsafe.NewExecutor[any](rp).WithObserver(unsampled).WithObserver(halfSampled1).WithObserver(halfSampled2)
+
+	// When
+	for i := 0; i < 100; i++ {
+		executor.RunWithExecution(testutil.RunFn(testutil.ErrInvalidState))
+	}
+
+	// Then
+	assert.Empty(t, unsampledHandler.Records())
+	records := halfSampledHandler1.Records()
+	assert.Greater(t, len(records), 0)
+	assert.Less(t, len(records), 200)
+	// Each execution's events should be logged together, by both loggers
+	assert.Equal(t, 0, len(records)%2)
+	assert.Equal(t, executionIDs(records), executionIDs(halfSampledHandler2.Records()))
+}
+
+func TestLoggerWithSampleRateShouldValidate(t *testing.T) {
+	assert.Panics(t, func() {
+		failsafe.NewLoggerBuilder(slog.Default()).WithSampleRate(-.1)
+	})
+	assert.Panics(t, func() {
+		failsafe.NewLoggerBuilder(slog.Default()).WithSampleRate(1.1)
+	})
+}
+
+// Tests that a CircuitBreaker's logger logs state transitions, including those that occur outside an execution.
+func TestCircuitBreakerLogger(t *testing.T) {
+	// Given
+	handler := &recordingHandler{level: slog.LevelDebug}
+	cb := circuitbreaker.Builder[any]().WithLogger(slog.New(handler)).Build()
+	var id string
+
+	// When
+	failsafe.NewExecutor[any](cb).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		id = exec.ID()
+		return testutil.ErrInvalidState
+	})
+	failsafe.NewExecutor[any](cb).RunWithExecution(testutil.RunFn(nil))
+	cb.Close()
+
+	// Then
+	records := handler.Records()
+	assert.Len(t, records, 3)
+	assert.Equal(t, "circuit breaker state changed", records[0].Message)
+	assert.Equal(t, slog.LevelWarn, records[0].Level)
+	assert.Equal(t, id, attrs(records[0])["execution_id"])
+	assert.Equal(t, "closed", attrs(records[0])["old_state"])
+	assert.Equal(t, "open", attrs(records[0])["new_state"])
+	assert.Equal(t, "circuit breaker rejected", records[1].Message)
+	assert.Equal(t, circuitbreaker.ErrOpen, attrs(records[1])["error"])
+	assert.Equal(t, map[string]any{
+		"policy":    "CircuitBreaker",
+		"old_state": "open",
+		"new_state": "closed",
+	}, attrs(records[2]))
+}
+
+// Tests that a policy's logger logs the policy's events without the executor being configured with a logger.
+func TestRetryPolicyLogger(t *testing.T) {
+	// Given
+	handler := &recordingHandler{level: slog.LevelDebug}
+	rp := retrypolicy.Builder[any]().WithMaxRetries(1).WithLogger(slog.New(handler)).Build()
+
+	// When
+	err := failsafe.NewExecutor[any](rp).RunWithExecution(testutil.RunFn(testutil.ErrInvalidState))
+
+	// Then
+	assert.ErrorIs(t, err, retrypolicy.ErrExceeded)
+	records := handler.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "retry scheduled", records[0].Message)
+	assert.Equal(t, "retries exceeded", records[1].Message)
+	assert.Equal(t, attrs(records[0])["execution_id"], attrs(records[1])["execution_id"])
+}
+
+// recordingHandler is a slog.Handler that records the records it handles.
+type recordingHandler struct {
+	level   slog.Level
+	mtx     sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *recordingHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.records = append(h.records, record)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *recordingHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+func (h *recordingHandler) Records() []slog.Record {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return append([]slog.Record(nil), h.records...)
+}
+
+func attrs(record slog.Record) map[string]any {
+	result := make(map[string]any)
+	record.Attrs(func(attr slog.Attr) bool {
+		result[attr.Key] = attr.Value.Any()
+		return true
+	})
+	return result
+}
+
+func executionIDs(records []slog.Record) []any {
+	var result []any
+	for _, record := range records {
+		result = append(result, attrs(record)["execution_id"])
+	}
+	return result
+}
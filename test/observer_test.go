@@ -0,0 +1,247 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+type observerKey struct{}
+
+// Tests that an observer is notified of executions, attempts, and policy events in order.
+func TestObserverWithRetries(t *testing.T) {
+	// Given
+	stub, _ := testutil.ErrorNTimesThenReturn[bool](testutil.ErrInvalidState, 2, true)
+	rp := retrypolicy.Builder[bool]().WithDelay(10 * time.Millisecond).Build()
+	observer := &recordingObserver{}
+
+	// When
+	result, err := failsafe.NewExecutor[bool](rp).WithObserver(observer).GetWithExecution(stub)
+
+	// Then
+	assert.True(t, result)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"execution started",
+		"attempt 1 started", "attempt 1 done: invalid state", "retry_scheduled RetryPolicy attempt 1 delay 10ms: invalid state",
+		"attempt 2 started", "attempt 2 done: invalid state", "retry_scheduled RetryPolicy attempt 2 delay 10ms: invalid state",
+		"attempt 3 started", "attempt 3 done",
+		"execution done after 3 attempts",
+	}, observer.Events())
+}
+
+func TestObserverWithRejectionsAndFallback(t *testing.T) {
+	// Given
+	cb := circuitbreaker.WithDefaults[bool]()
+	cb.Open()
+	rp := retrypolicy.Builder[bool]().WithMaxRetries(1).Build()
+	fb := fallback.WithResult(true)
+	observer := &recordingObserver{}
+
+	// When
+	result, err := failsafe.NewExecutor[bool](fb, rp, cb).WithObserver(observer).GetWithExecution(testutil.GetFn(false, nil))
+
+	// Then
+	assert.True(t, result)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"execution started",
+		"circuit_breaker_rejected CircuitBreaker attempt 1: circuit breaker open",
+		"retry_scheduled RetryPolicy attempt 1 delay 0s: circuit breaker open",
+		"circuit_breaker_rejected CircuitBreaker attempt 2: circuit breaker open",
+		"retries_exceeded RetryPolicy attempt 2: circuit breaker open",
+		"fallback_executed Fallback attempt 2: retries exceeded. last result: false, last error: circuit breaker open",
+		"execution done after 2 attempts",
+	}, observer.Events())
+}
+
+func TestObserverWithCircuitBreakerStateChanges(t *testing.T) {
+	// Given
+	cb := circuitbreaker.Builder[bool]().WithFailureThreshold(2).WithDelay(10 * time.Millisecond).Build()
+	rp := retrypolicy.Builder[bool]().WithMaxRetries(2).Build()
+	observer := &recordingObserver{}
+
+	// When
+	_, err := failsafe.NewExecutor[bool](rp, cb).WithObserver(observer).GetWithExecution(testutil.GetFn(false, testutil.ErrInvalidState))
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	time.Sleep(20 * time.Millisecond)
+	result, err := failsafe.NewExecutor[bool](cb).WithObserver(observer).GetWithExecution(testutil.GetFn(true, nil))
+
+	// Then
+	assert.True(t, result)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"execution started",
+		"attempt 1 started", "attempt 1 done: invalid state", "retry_scheduled RetryPolicy attempt 1 delay 0s: invalid state",
+		"attempt 2 started", "attempt 2 done: invalid state", "circuit_breaker_state_changed CircuitBreaker attempt 2 closed to open",
+		"retry_scheduled RetryPolicy attempt 2 delay 0s: invalid state",
+		"circuit_breaker_rejected CircuitBreaker attempt 3: circuit breaker open",
+		"retries_exceeded RetryPolicy attempt 3: circuit breaker open",
+		"execution done after 3 attempts: retries exceeded. last result: false, last error: circuit breaker open",
+		"execution started",
+		"circuit_breaker_state_changed CircuitBreaker attempt 1 open to half-open",
+		"attempt 1 started", "attempt 1 done", "circuit_breaker_state_changed CircuitBreaker attempt 1 half-open to closed",
+		"execution done after 1 attempts",
+	}, observer.Events())
+}
+
+func TestObserverWithTimeout(t *testing.T) {
+	// Given
+	to := timeout.With[any](10 * time.Millisecond)
+	observer := &recordingObserver{}
+
+	// When
+	err := failsafe.NewExecutor[any](to).WithObserver(observer).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		<-exec.Canceled()
+		return exec.Context().Err()
+	})
+
+	// Then
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	assert.Equal(t, []string{
+		"execution started",
+		"attempt 1 started",
+		"timeout_exceeded Timeout attempt 1: timeout exceeded",
+		"attempt 1 done: context canceled",
+		"execution done after 1 attempts: timeout exceeded",
+	}, observer.Events())
+}
+
+func TestObserverWithHedges(t *testing.T) {
+	// Given
+	hp := hedgepolicy.BuilderWithDelay[bool](10 * time.Millisecond).
+		CancelIf(func(_ bool, err error) bool {
+			return err == nil
+		}).
+		Build()
+	observer := &recordingObserver{}
+
+	// When
+	result, err := failsafe.NewExecutor[bool](hp).WithObserver(observer).GetWithExecution(func(exec failsafe.Execution[bool]) (bool, error) {
+		if !exec.IsHedge() {
+			<-exec.Canceled()
+			return false, exec.Context().Err()
+		}
+		return true, nil
+	})
+
+	// Then
+	assert.True(t, result)
+	assert.NoError(t, err)
+	events := observer.Events()
+	assert.Contains(t, events, "hedge_started HedgePolicy attempt 2")
+	assert.Contains(t, events, "attempt 2 done")
+}
+
+// Tests that contexts returned by observers are provided to executions and attempts.
+func TestObserverContexts(t *testing.T) {
+	// Given
+	rp := retrypolicy.WithDefaults[any]()
+	observer1 := &recordingObserver{name: "observer1"}
+	observer2 := &recordingObserver{name: "observer2"}
+	var values []any
+
+	// When
+	failsafe.NewExecutor[any](rp).
+		WithObserver(observer1).
+		WithObserver(observer2).
+		RunWithExecution(func(exec failsafe.Execution[any]) error {
+			values = append(values, exec.Context().Value(observerKey{}))
+			if exec.IsFirstAttempt() {
+				return testutil.ErrInvalidState
+			}
+			return nil
+		})
+
+	// Then
+	assert.Equal(t, []any{"observer2 attempt 1", "observer2 attempt 2"}, values)
+	assert.Equal(t, []string{"observer1 execution", "observer2 execution"}, observer2.ExecutionContextValues())
+	assert.Equal(t, observer1.Events(), observer2.Events())
+}
+
+func TestPolicyEventTypeString(t *testing.T) {
+	assert.Equal(t, "retry_scheduled", failsafe.RetryScheduled.String())
+	assert.Equal(t, "cache_miss", failsafe.CacheMiss.String())
+	assert.Equal(t, "fault_injected", failsafe.FaultInjected.String())
+	assert.Equal(t, "unknown", failsafe.PolicyEventType(0).String())
+}
+
+// recordingObserver records observed events as strings, and stores a value in the contexts that it returns.
+type recordingObserver struct {
+	name                   string
+	mtx                    sync.Mutex
+	events                 []string
+	executionContextValues []string
+}
+
+func (o *recordingObserver) ExecutionStarted(ctx context.Context) context.Context {
+	o.record("execution started", nil)
+	if value, ok := ctx.Value(observerKey{}).(string); ok {
+		o.mtx.Lock()
+		o.executionContextValues = append(o.executionContextValues, value)
+		o.mtx.Unlock()
+	}
+	return context.WithValue(ctx, observerKey{}, o.name+" execution")
+}
+
+func (o *recordingObserver) AttemptStarted(ctx context.Context, attempt failsafe.AttemptStats) context.Context {
+	o.record(fmt.Sprintf("attempt %d started", attempt.Attempts()), nil)
+	return context.WithValue(ctx, observerKey{}, fmt.Sprintf("%s attempt %d", o.name, attempt.Attempts()))
+}
+
+func (o *recordingObserver) AttemptDone(_ context.Context, attempt failsafe.AttemptStats, err error) {
+	o.record(fmt.Sprintf("attempt %d done", attempt.Attempts()), err)
+}
+
+func (o *recordingObserver) PolicyEvent(_ context.Context, event failsafe.PolicyEvent) {
+	description := fmt.Sprintf("%s %s attempt %d", event.Type, event.Policy, event.Attempts())
+	if event.Type == failsafe.RetryScheduled {
+		description += fmt.Sprintf(" delay %s", event.Delay)
+	}
+	if event.Type == failsafe.CircuitBreakerStateChanged {
+		description += fmt.Sprintf(" %s to %s", event.OldState, event.NewState)
+	}
+	o.record(description, event.Error)
+}
+
+func (o *recordingObserver) ExecutionDone(ctx context.Context, stats failsafe.ExecutionStats, err error) {
+	o.record(fmt.Sprintf("execution done after %d attempts", stats.Attempts()), err)
+	if value, ok := ctx.Value(observerKey{}).(string); ok {
+		o.mtx.Lock()
+		o.executionContextValues = append(o.executionContextValues, value)
+		o.mtx.Unlock()
+	}
+}
+
+func (o *recordingObserver) record(description string, err error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if err != nil {
+		description += ": " + err.Error()
+	}
+	o.events = append(o.events, description)
+}
+
+func (o *recordingObserver) Events() []string {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return append([]string(nil), o.events...)
+}
+
+func (o *recordingObserver) ExecutionContextValues() []string {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return append([]string(nil), o.executionContextValues...)
+}
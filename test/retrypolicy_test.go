@@ -99,6 +99,23 @@ func TestShouldNotRetryOnNonRetriableFailure(t *testing.T) {
 		AssertSuccess(3, 3, 0)
 }
 
+// Tests that retries are aborted when the execution matches an AbortIfExecution predicate.
+func TestShouldAbortIfExecution(t *testing.T) {
+	// Given
+	rp := retrypolicy.Builder[bool]().
+		WithMaxRetries(-1).
+		AbortIfExecution(func(exec failsafe.Execution[bool]) bool {
+			return exec.Attempts() == 2 && errors.Is(exec.LastError(), testutil.ErrConnecting)
+		}).
+		Build()
+
+	// When / Then
+	testutil.Test[bool](t).
+		With(rp).
+		Get(testutil.GetFn(false, testutil.ErrConnecting)).
+		AssertFailure(2, 2, testutil.ErrConnecting)
+}
+
 // Asserts that an execution is failed when the max duration is exceeded.
 func TestShouldFailWhenMaxDurationExceeded(t *testing.T) {
 	// Given
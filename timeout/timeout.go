@@ -2,6 +2,7 @@ package timeout
 
 import (
 	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
@@ -24,6 +25,9 @@ type TimeoutBuilder[R any] interface {
 	// OnTimeoutExceeded registers the listener to be called when the timeout is exceeded.
 	OnTimeoutExceeded(listener func(event failsafe.ExecutionDoneEvent[R])) TimeoutBuilder[R]
 
+	// WithLogger configures the logger to log the Timeout's events to. See failsafe.Logger.
+	WithLogger(logger *slog.Logger) TimeoutBuilder[R]
+
 	// Build returns a new Timeout using the builder's configuration.
 	Build() Timeout[R]
 }
@@ -31,6 +35,7 @@ type TimeoutBuilder[R any] interface {
 type timeoutConfig[R any] struct {
 	timeLimit         time.Duration
 	onTimeoutExceeded func(failsafe.ExecutionDoneEvent[R])
+	logger            *failsafe.Logger
 }
 
 var _ TimeoutBuilder[any] = &timeoutConfig[any]{}
@@ -62,6 +67,11 @@ func (c *timeoutConfig[R]) OnTimeoutExceeded(listener func(event failsafe.Execut
 	return c
 }
 
+func (c *timeoutConfig[R]) WithLogger(logger *slog.Logger) TimeoutBuilder[R] {
+	c.logger = failsafe.NewLoggerBuilder(logger).Build()
+	return c
+}
+
 func (c *timeoutConfig[R]) Build() Timeout[R] {
 	fbCopy := *c
 	return &timeout[R]{
@@ -71,8 +81,10 @@ func (c *timeoutConfig[R]) Build() Timeout[R] {
 
 func (t *timeout[R]) ToExecutor(_ R) any {
 	te := &timeoutExecutor[R]{
-		BaseExecutor: &policy.BaseExecutor[R]{},
-		timeout:      t,
+		BaseExecutor: &policy.BaseExecutor[R]{
+			Logger: t.config.logger,
+		},
+		timeout: t,
 	}
 	te.Executor = te
 	return te